@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/aldrickdev/dbm-sandbox/internal/apikey"
+	"github.com/aldrickdev/dbm-sandbox/internal/styles"
+)
+
+const (
+	// stdinPath is the value of --api-key-file used to read the API Key from
+	// stdin.
+	stdinPath = "-"
+)
+
+var (
+	// apiKeyFile is the file the Datadog API Key should be read from, "-" reads
+	// it from stdin.
+	apiKeyFile string
+	// placeholderKey generates the project with a dummy API Key.
+	placeholderKey bool
+)
+
+func init() {
+	rootCmd.Flags().StringVar(&apiKeyFile, "api-key-file", "", `read the Datadog API Key from a file, use "-" to read it from stdin`)
+	rootCmd.Flags().BoolVar(&placeholderKey, "placeholder-key", false, "generate the project with a clearly marked dummy API Key, useful to only inspect the files")
+}
+
+// getAPIKey returns the Datadog API Key that should be injected into the
+// project. The key is looked up in the following order: the placeholder key
+// if requested, the --api-key-file flag, the DD_API_KEY environment variable
+// and finally a masked prompt.
+func getAPIKey() (string, error) {
+	key, err := lookupAPIKey()
+	if err != nil {
+		return "", err
+	}
+
+	if apikey.IsPlaceholder(key) {
+		fmt.Fprintln(messages, styles.Warning.Render("Using a placeholder API Key, the agent will not be able to send data to Datadog"))
+		return key, nil
+	}
+
+	if !apikey.IsWellFormed(key) {
+		fmt.Fprintln(messages, styles.Warning.Render("The API Key provided doesn't look like a Datadog API Key (32 hex characters), continuing anyway"))
+	}
+
+	return key, nil
+}
+
+// lookupAPIKey finds the API Key from the supported sources without checking
+// its format.
+func lookupAPIKey() (string, error) {
+	if placeholderKey {
+		return apikey.Placeholder, nil
+	}
+
+	if apiKeyFile == stdinPath {
+		// Only the first line is read so that the rest of stdin can still be
+		// used to answer the questions.
+		key, err := console.ReadLine()
+		if err != nil || key == "" {
+			return "", fmt.Errorf("No API Key found in stdin")
+		}
+		return key, nil
+	}
+
+	if apiKeyFile != "" {
+		return apikey.FromFile(apiKeyFile)
+	}
+
+	if key, ok := apikey.FromEnv(); ok {
+		return key, nil
+	}
+
+	var key string
+	prompt := fmt.Sprintf("The %q environment variable is not set, what is your Datadog API Key?", apikey.ENV)
+	if err := newSecretRunner(prompt, &key).Run(); err != nil {
+		return "", err
+	}
+
+	if key == "" {
+		return "", fmt.Errorf("No API Key provided, set %q, use --api-key-file or --placeholder-key", apikey.ENV)
+	}
+
+	return key, nil
+}
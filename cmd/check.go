@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/aldrickdev/dbm-sandbox/internal/providers"
+	"github.com/aldrickdev/dbm-sandbox/internal/scenarios"
+	"github.com/aldrickdev/dbm-sandbox/internal/styles"
+	"github.com/aldrickdev/dbm-sandbox/internal/utils/components/progress"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	// checkDetails shows the result of every check, which hints at what is
+	// broken.
+	checkDetails bool
+	// checkReveal shows the answer key of the scenario.
+	checkReveal bool
+)
+
+var checkCmd = &cobra.Command{
+	Use:   "check [directory]",
+	Short: "Check whether a broken sandbox has been fixed",
+	Long:  `Check whether the configuration of a Docker or Podman sandbox, broken by a troubleshooting scenario, has been fixed. The project directory defaults to the working directory.`,
+	Args:  cobra.MaximumNArgs(1),
+	// A sandbox that isn't fixed yet isn't a usage error
+	SilenceUsage: true,
+	RunE:         runCheck,
+}
+
+func init() {
+	checkCmd.Flags().BoolVar(&checkDetails, "details", false, "show the result of every check, which hints at what is broken")
+	checkCmd.Flags().BoolVar(&checkReveal, "reveal", false, "show the answer key of the scenario")
+
+	rootCmd.AddCommand(checkCmd)
+}
+
+func runCheck(cmd *cobra.Command, args []string) error {
+	dir := "."
+	if len(args) == 1 {
+		dir = args[0]
+	}
+
+	scenario, applied, err := scenarios.Applied(dir)
+	if err != nil {
+		return err
+	}
+
+	dbms := scenario.DBMS
+	if applied {
+		fmt.Printf("Scenario: %s\nSymptom: %s\n", scenario.Name, scenario.Symptom)
+	} else {
+		dbms, err = scenarios.DetectDBMS(dir)
+		if err != nil {
+			return err
+		}
+	}
+
+	results := scenarios.RunChecks(dir, dbms)
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+
+		if !checkDetails {
+			continue
+		}
+
+		if result.Err != nil {
+			fmt.Printf("  %s %s: %s\n", progress.Mark(progress.Failed), result.Check, result.Err)
+		} else {
+			fmt.Printf("  %s %s\n", progress.Mark(progress.Done), result.Check)
+		}
+	}
+
+	if checkReveal && applied {
+		// The answer key uses the compose command of the sandbox, projects
+		// generated before the manifest are Docker ones
+		manifest, ok, err := providers.ReadManifest(dir)
+		if err != nil {
+			return err
+		}
+		compose := "docker compose"
+		if ok {
+			compose = manifest.Compose
+		}
+
+		fmt.Printf("Answer key: %s\n", scenario.Answer(compose))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("The sandbox isn't fixed yet, %d of the %d checks fail", failed, len(results))
+	}
+
+	fmt.Println(styles.Success.Render("The sandbox is fixed"))
+	return nil
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aldrickdev/dbm-sandbox/internal/config"
+	"github.com/aldrickdev/dbm-sandbox/internal/providers"
+	"github.com/aldrickdev/dbm-sandbox/internal/styles"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	// configPath is the location of the config file.
+	configPath string
+	// catalogPath is the location of the file that overrides the version
+	// catalog.
+	catalogPath string
+	// packsPath is the location of the directory the template packs are
+	// installed in.
+	packsPath string
+	// cfg is the loaded config, with the flags already applied on top.
+	cfg config.Config
+)
+
+func init() {
+	defaultPath, err := config.Path()
+	if err != nil {
+		defaultPath = ""
+	}
+
+	defaultCatalogPath, err := config.CatalogPath()
+	if err != nil {
+		defaultCatalogPath = ""
+	}
+
+	defaultPacksPath, err := config.PacksPath()
+	if err != nil {
+		defaultPacksPath = ""
+	}
+
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", defaultPath, "location of the config file")
+	rootCmd.PersistentFlags().StringVar(&catalogPath, "catalog", defaultCatalogPath, "location of the file that overrides the version catalog")
+	rootCmd.PersistentFlags().StringVar(&packsPath, "packs-dir", defaultPacksPath, "location of the directory the template packs are installed in")
+	rootCmd.PersistentFlags().StringVar(&cfg.Theme, "theme", styles.DarkTheme.Name, fmt.Sprintf("theme used to render the TUI, one of: %s", strings.Join(styles.ThemeNames(), ", ")))
+	rootCmd.PersistentFlags().BoolVar(&cfg.ASCII, "ascii", false, "replace the emojis and the banner with plain ASCII text")
+	rootCmd.PersistentFlags().StringVar(&cfg.TemplatesDir, "templates-dir", "", "directory of templates that replace, or are added to, the embedded templates")
+
+	rootCmd.PersistentPreRunE = loadConfig
+}
+
+// loadConfig loads the config file, applies the flags the user set on top of
+// it, sets up the styles, applies the version catalog and templates overrides
+// and finds the template packs.
+func loadConfig(cmd *cobra.Command, args []string) error {
+	if catalogPath != "" {
+		// The default catalog file is optional, one passed with the flag isn't
+		if err := providers.LoadCatalog(catalogPath, cmd.Flags().Changed("catalog")); err != nil {
+			return err
+		}
+	}
+
+	providers.SetPacksDir(packsPath)
+
+	if configPath != "" {
+		fileCfg, err := config.Load(configPath)
+		if err != nil {
+			return err
+		}
+
+		flags := cmd.Flags()
+		if !flags.Changed("theme") && fileCfg.Theme != "" {
+			cfg.Theme = fileCfg.Theme
+		}
+		if !flags.Changed("ascii") {
+			cfg.ASCII = fileCfg.ASCII
+		}
+		if !flags.Changed("templates-dir") && fileCfg.TemplatesDir != "" {
+			cfg.TemplatesDir = fileCfg.TemplatesDir
+		}
+	}
+
+	if cfg.TemplatesDir != "" {
+		if err := providers.SetTemplatesDir(cfg.TemplatesDir); err != nil {
+			return err
+		}
+	}
+
+	theme, err := styles.GetTheme(cfg.Theme)
+	if err != nil {
+		return err
+	}
+	styles.Apply(theme, cfg.ASCII)
+
+	return nil
+}
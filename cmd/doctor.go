@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/aldrickdev/dbm-sandbox/internal/doctor"
+	"github.com/aldrickdev/dbm-sandbox/internal/providers"
+	"github.com/aldrickdev/dbm-sandbox/internal/styles"
+	"github.com/aldrickdev/dbm-sandbox/internal/utils/components/progress"
+
+	"github.com/spf13/cobra"
+)
+
+// doctorOutput is how the results are printed, plain or json.
+var doctorOutput string
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the prerequisites of the sandboxes",
+	Long:  `Check that docker and docker compose, or podman, are installed and that their daemon is reachable, that the ports of the sandboxes are free, that there is enough disk space and that the Datadog API Key is well-formed`,
+	Args:  cobra.NoArgs,
+	// A failed check isn't a usage error
+	SilenceUsage: true,
+	RunE:         runDoctor,
+}
+
+func init() {
+	doctorCmd.Flags().StringVarP(&doctorOutput, "output", "o", plainOutput, fmt.Sprintf("how the results are printed, one of: %s, %s", plainOutput, jsonOutput))
+
+	rootCmd.AddCommand(doctorCmd)
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	if doctorOutput != plainOutput && doctorOutput != jsonOutput {
+		return fmt.Errorf("Unknown output mode %q, expected one of: %q", doctorOutput, []string{plainOutput, jsonOutput})
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("Failed to get the working directory, error: %q", err)
+	}
+
+	results := doctor.Run(doctor.System(dir, sandboxPorts()))
+
+	if doctorOutput == jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(results); err != nil {
+			return err
+		}
+	} else {
+		printDoctorResults(os.Stdout, results)
+	}
+
+	if doctor.Failed(results) {
+		// The results already describe the failures
+		cmd.SilenceErrors = true
+		return fmt.Errorf("Some prerequisites aren't met")
+	}
+
+	return nil
+}
+
+// sandboxPorts returns the ports of the DBMS, sorted by number.
+func sandboxPorts() []doctor.Port {
+	ports := []doctor.Port{}
+	for name, number := range providers.DBMSPorts() {
+		ports = append(ports, doctor.Port{Name: name, Number: number})
+	}
+
+	sort.Slice(ports, func(i, j int) bool {
+		return ports[i].Number < ports[j].Number
+	})
+
+	return ports
+}
+
+// printDoctorResults prints a line per result, followed by its hint.
+func printDoctorResults(out io.Writer, results []doctor.Result) {
+	warnings, failures := 0, 0
+	for _, result := range results {
+		fmt.Fprintf(out, "  %s %s: %s\n", doctorMark(result.Status), result.Check, result.Detail)
+		if result.Hint != "" {
+			fmt.Fprintf(out, "      %s\n", result.Hint)
+		}
+
+		switch result.Status {
+		case doctor.Warn:
+			warnings++
+
+		case doctor.Fail:
+			failures++
+		}
+	}
+	fmt.Fprintln(out)
+
+	switch {
+	case failures > 0:
+		fmt.Fprintln(out, styles.Error.Render(fmt.Sprintf("Not ready, failed: %d, warnings: %d", failures, warnings)))
+
+	case warnings > 0:
+		fmt.Fprintln(out, styles.Warning.Render(fmt.Sprintf("Ready, warnings: %d", warnings)))
+
+	default:
+		fmt.Fprintln(out, styles.Success.Render("Ready to create sandboxes"))
+	}
+}
+
+// doctorMark returns the mark shown next to a result.
+func doctorMark(status doctor.Status) string {
+	switch status {
+	case doctor.Pass:
+		return progress.Mark(progress.Done)
+
+	case doctor.Fail:
+		return progress.Mark(progress.Failed)
+
+	default:
+		if styles.ASCIIOnly {
+			return "[!]"
+		}
+		return "!"
+	}
+}
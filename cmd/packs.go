@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aldrickdev/dbm-sandbox/internal/packs"
+	"github.com/aldrickdev/dbm-sandbox/internal/providers"
+	"github.com/aldrickdev/dbm-sandbox/internal/styles"
+
+	"github.com/spf13/cobra"
+)
+
+// forceInstall replaces an installed pack with the same name.
+var forceInstall bool
+
+var packsCmd = &cobra.Command{
+	Use:   "packs",
+	Short: "Manage the template packs",
+	Long:  `Manage the template packs, named bundles of templates and questions that set up a scenario in the Docker and Podman sandboxes`,
+}
+
+var packsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the installed template packs",
+	Args:  cobra.NoArgs,
+	Run:   runPacksList,
+}
+
+var packsInstallCmd = &cobra.Command{
+	Use:   "install <directory|tarball>",
+	Short: "Install a template pack from a directory or a tarball",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPacksInstall,
+}
+
+func init() {
+	packsInstallCmd.Flags().BoolVar(&forceInstall, "force", false, "replace an installed pack with the same name")
+
+	packsCmd.AddCommand(packsListCmd, packsInstallCmd)
+	rootCmd.AddCommand(packsCmd)
+}
+
+func runPacksList(cmd *cobra.Command, args []string) {
+	installed, errs := providers.InstalledPacks()
+	for _, err := range errs {
+		fmt.Println(styles.Warning.Render(err.Error()))
+	}
+
+	if len(installed) == 0 {
+		fmt.Printf("No template pack is installed in %q\n", packsPath)
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tVERSION\tDBMS\tDESCRIPTION")
+	for _, pack := range installed {
+		dbms := "any"
+		if len(pack.DBMS) > 0 {
+			dbms = strings.Join(pack.DBMS, ", ")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", pack.Name, pack.Version, dbms, pack.Description)
+	}
+	w.Flush()
+}
+
+func runPacksInstall(cmd *cobra.Command, args []string) error {
+	if packsPath == "" {
+		return fmt.Errorf("Failed to find the packs directory, use --packs-dir to set it")
+	}
+
+	pack, err := packs.Install(args[0], packsPath, forceInstall)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(styles.Success.Render(fmt.Sprintf("The pack %q %s has been installed", pack.Name, pack.Version)))
+	return nil
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/aldrickdev/dbm-sandbox/internal/providers"
+	"github.com/aldrickdev/dbm-sandbox/internal/utils/components/progress"
+)
+
+const (
+	tuiOutput   = "tui"
+	plainOutput = "plain"
+	jsonOutput  = "json"
+)
+
+var (
+	// outputMode is how the progress of the provider is shown, one of tui,
+	// plain or json. When empty it is picked based on the terminal.
+	outputMode string
+	// startProject starts the sandbox once the project was generated.
+	startProject bool
+)
+
+func init() {
+	rootCmd.Flags().StringVarP(&outputMode, "output", "o", "", fmt.Sprintf("how the progress is shown, one of: %s, %s, %s (default %s when running in a terminal, %s otherwise)", tuiOutput, plainOutput, jsonOutput, tuiOutput, plainOutput))
+	rootCmd.Flags().BoolVar(&startProject, "up", false, "start the sandbox once the project is generated, when the provider supports it")
+}
+
+// getOutputMode returns the output mode that should be used for the progress.
+func getOutputMode() (string, error) {
+	switch outputMode {
+	case "":
+		if useTUI() {
+			return tuiOutput, nil
+		}
+		return plainOutput, nil
+
+	case tuiOutput, plainOutput, jsonOutput:
+		return outputMode, nil
+
+	default:
+		return "", fmt.Errorf("Unknown output mode %q, expected one of: %q", outputMode, []string{tuiOutput, plainOutput, jsonOutput})
+	}
+}
+
+// runWithProgress runs the job while showing the events emitted by the
+// provider using the output mode. The context of the job is cancelled when the
+// user interrupts it, and runWithProgress waits for the job to return.
+func runWithProgress(mode string, provider providers.Provider, title string, job func(context.Context) error) error {
+	switch mode {
+	case jsonOutput:
+		encoder := json.NewEncoder(os.Stdout)
+		provider.SetEventHandler(func(event providers.Event) {
+			encoder.Encode(event)
+		})
+		return runInterruptible(job)
+
+	case plainOutput:
+		fmt.Println(title)
+		provider.SetEventHandler(func(event providers.Event) {
+			fmt.Println(plainEvent(event))
+		})
+		return runInterruptible(job)
+
+	default:
+		return progress.NewProgress(title).Run(func(ctx context.Context, update progress.Update) error {
+			provider.SetEventHandler(func(event providers.Event) {
+				update(event.Step, progressStatus(event.Status))
+			})
+			return job(ctx)
+		})
+	}
+}
+
+// runInterruptible runs the job with a context that is cancelled on Ctrl+C,
+// instead of exiting while the job is still running.
+func runInterruptible(job func(context.Context) error) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	return job(ctx)
+}
+
+// plainEvent formats the event as a single line of plain text.
+func plainEvent(event providers.Event) string {
+	line := fmt.Sprintf("  %s %s", progress.Mark(progressStatus(event.Status)), event.Step)
+	if event.Error != "" {
+		line += ": " + event.Error
+	}
+
+	return line
+}
+
+// progressStatus converts the status of a provider step to the status used by
+// the progress view.
+func progressStatus(status providers.StepStatus) progress.Status {
+	switch status {
+	case providers.StepSucceeded:
+		return progress.Done
+
+	case providers.StepFailed:
+		return progress.Failed
+
+	default:
+		return progress.Running
+	}
+}
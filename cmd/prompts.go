@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/aldrickdev/dbm-sandbox/internal/providers"
+	"github.com/aldrickdev/dbm-sandbox/internal/utils/components/picker"
+	"github.com/aldrickdev/dbm-sandbox/internal/utils/components/plain"
+	"github.com/aldrickdev/dbm-sandbox/internal/utils/components/textInput"
+
+	"github.com/mattn/go-isatty"
+)
+
+var (
+	// noTUI forces the plain line based prompts even when a terminal is
+	// available.
+	noTUI bool
+
+	// console is the line based terminal shared by all of the plain prompts.
+	console = plain.NewConsole(os.Stdin, os.Stdout)
+)
+
+func init() {
+	rootCmd.Flags().BoolVar(&noTUI, "no-tui", false, "use plain line based prompts instead of the interactive TUI")
+}
+
+// useTUI reports whether the Bubble Tea prompts can be used. They require both
+// stdin and stdout to be terminals.
+func useTUI() bool {
+	if noTUI {
+		return false
+	}
+
+	return isTerminal(os.Stdin) && isTerminal(os.Stdout)
+}
+
+// isTerminal reports whether the file is an interactive terminal.
+func isTerminal(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// newQuestionRunner returns the RunnableQuestion that should be used to ask the
+// question, depending on if the TUI is available or not.
+func newQuestionRunner(question *providers.Question) providers.RunnableQuestion {
+	switch question.QType {
+	case providers.Picker:
+		if !useTUI() {
+			return console.NewPicker(question.Options, question.OptionDescriptions, question.Prompt, &question.Answer).
+				WithDefault(question.DefaultAnswer).
+				WithDisabled(question.DisabledOptions)
+		}
+		return picker.NewPicker(question.Options, question.OptionDescriptions, question.Prompt, &question.Answer).
+			WithDefault(question.DefaultAnswer).
+			WithDisabled(question.DisabledOptions)
+
+	case providers.Input:
+		if !useTUI() {
+			return console.NewTextInput(question.Prompt, question.DefaultAnswer, &question.Answer)
+		}
+		return textInput.NewTextInput(question.Prompt, question.DefaultAnswer, &question.Answer)
+
+	default:
+		return nil
+	}
+}
+
+// newSecretRunner returns the RunnableQuestion that should be used to ask for a
+// secret, depending on if the TUI is available or not.
+func newSecretRunner(prompt string, output *string) providers.RunnableQuestion {
+	if !useTUI() {
+		return console.NewSecretInput(prompt, output)
+	}
+
+	return textInput.NewSecretInput(prompt, output)
+}
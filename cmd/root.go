@@ -1,21 +1,18 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/aldrickdev/dbm-sandbox/internal/providers"
 	"github.com/aldrickdev/dbm-sandbox/internal/styles"
-	"github.com/aldrickdev/dbm-sandbox/internal/utils/components/picker"
-	"github.com/aldrickdev/dbm-sandbox/internal/utils/components/textInput"
+	"github.com/aldrickdev/dbm-sandbox/internal/utils/components/plain"
 
 	"github.com/spf13/cobra"
 )
 
-const (
-	DATADOG_API_KEY_ENV = "DD_API_KEY"
-)
-
 var rootCmd = &cobra.Command{
 	Use:   "dbm-sandbox",
 	Short: "Create a DBM sandbox",
@@ -23,6 +20,18 @@ var rootCmd = &cobra.Command{
 	Run:   run,
 }
 
+// banner is displayed when the tool starts.
+const banner = `       ____                                        ____              
+  ____/ / /_  ____ ___       _________ _____  ____/ / /_  ____  _  __
+ / __  / __ \/ __ '__ \     / ___/ __ '/ __ \/ __  / __ \/ __ \| |/_/
+/ /_/ / /_/ / / / / / /    (__  ) /_/ / / / / /_/ / /_/ / /_/ />  <  
+\__,_/_.___/_/ /_/ /_/    /____/\__,_/_/ /_/\__,_/_.___/\____/_/|_|
+`
+
+// messages is where the messages meant for the user are printed. It is
+// stderr in the JSON output mode so that stdout only contains events.
+var messages io.Writer = os.Stdout
+
 func Execute() {
 	err := rootCmd.Execute()
 	if err != nil {
@@ -31,42 +40,55 @@ func Execute() {
 }
 
 func run(cmd *cobra.Command, args []string) {
-	// Displays the initial Banner
-	title := `       ____                                        ____              
-  ____/ / /_  ____ ___       _________ _____  ____/ / /_  ____  _  __
- / __  / __ \/ __ '__ \     / ___/ __ '/ __ \/ __  / __ \/ __ \| |/_/
-/ /_/ / /_/ / / / / / /    (__  ) /_/ / / / / /_/ / /_/ / /_/ />  <  
-\__,_/_.___/_/ /_/ /_/    /____/\__,_/_/ /_/\__,_/_.___/\____/_/|_|
-`
-	fmt.Print(styles.ProjectTitle.Render(title))
+	// Check the output mode before asking any questions
+	mode, err := getOutputMode()
+	if err != nil {
+		fmt.Fprintln(messages, styles.Error.Render(err.Error()))
+		return
+	}
 
-	initialText := "Welcome to the dbm sandboxing tool, where the goal is to help you create DBM sandboxes."
-	fmt.Print(styles.Question.Render(initialText))
+	// The prompts and messages go to stderr in the JSON output mode
+	if mode == jsonOutput {
+		messages = os.Stderr
+		console = plain.NewConsole(os.Stdin, os.Stderr)
+	}
+
+	// Displays the initial Banner, skipped for the JSON output mode
+	if mode != jsonOutput {
+		title := banner
+		// The banner is ASCII art which screen readers can't make sense of
+		if styles.ASCIIOnly {
+			title = "dbm-sandbox\n"
+		}
+		fmt.Print(styles.ProjectTitle.Render(title))
+
+		initialText := "Welcome to the dbm sandboxing tool, where the goal is to help you create DBM sandboxes."
+		fmt.Print(styles.Question.Render(initialText))
+	}
 
 	// Gets the Datadog API Key
-	ddapikey, ok := os.LookupEnv(DATADOG_API_KEY_ENV)
-	if !ok {
-		errorMsg := fmt.Sprintf("Failed to find your %q, please make sure to have the environment variable set", DATADOG_API_KEY_ENV)
-		fmt.Println(styles.Error.Render(errorMsg))
+	ddapikey, err := getAPIKey()
+	if err != nil {
+		errorMsg := fmt.Sprintf("Failed to get your Datadog API Key: %q", err)
+		fmt.Fprintln(messages, styles.Error.Render(errorMsg))
 		return
 	}
 
-	// Gets a list of the available providers
-	var selectedProvider string
-	pickProvider := picker.NewPicker(
-		providers.GetAvailableProviders(),
-		providers.GetProviderDescriptions(),
-		"What provider would you like to use?",
-		&selectedProvider,
-	)
+	// Register the external providers found on the PATH, a broken external
+	// provider shouldn't stop the user from using the others
+	for _, err := range providers.RegisterExternalProviders() {
+		fmt.Fprintln(messages, styles.Warning.Render(err.Error()))
+	}
 
-	// Prompt the user to select a provider
-	if err := pickProvider.Run(); err != nil {
+	// Prompt the user to select one of the available providers
+	providerQuestion := providers.GetProviderQuestion()
+	if err := newQuestionRunner(providerQuestion).Run(); err != nil {
 		errorMsg := fmt.Sprintf("Error Running Program: %q", err)
-		fmt.Println(styles.Error.Render(errorMsg))
+		fmt.Fprintln(messages, styles.Error.Render(errorMsg))
 		return
 	}
 
+	selectedProvider := providerQuestion.Answer
 	if selectedProvider == "" {
 		return
 	}
@@ -75,7 +97,7 @@ func run(cmd *cobra.Command, args []string) {
 	provider := providers.GetProvider(selectedProvider)
 	if provider == nil {
 		errorMsg := fmt.Sprintf("Provider %q not implemented", selectedProvider)
-		fmt.Println(styles.Error.Render(errorMsg))
+		fmt.Fprintln(messages, styles.Error.Render(errorMsg))
 		return
 	}
 
@@ -85,33 +107,78 @@ func run(cmd *cobra.Command, args []string) {
 	// Loop over each of the provider questions
 	for _, questionFunc := range questions {
 		question := questionFunc()
+		// The question doesn't apply to the previous answers
+		if question == nil {
+			continue
+		}
+
+		if !askQuestion(question) {
+			return
+		}
+	}
 
-		var runner providers.RunnableQuestion
+	// Have the provider generate the project directory
+	if err := runWithProgress(mode, provider, "Generating your project", func(context.Context) error {
+		return provider.GenerateProject(ddapikey)
+	}); err != nil {
+		errorMsg := fmt.Sprintf("Error generating project: %q", err)
+		fmt.Fprintln(messages, styles.Error.Render(errorMsg))
+		return
+	}
 
-		switch question.QType {
-		case providers.Picker:
-			runner = picker.NewPicker(question.Options, nil, question.Prompt, &question.Answer)
+	if !startProject {
+		printResult(mode, "Your project has been created")
+		return
+	}
 
-		case providers.Input:
-			runner = textInput.NewTextInput(question.Prompt, question.DefaultAnswer, &question.Answer)
-		}
+	// Have the provider start the project it generated
+	starter, ok := provider.(providers.Starter)
+	if !ok {
+		errorMsg := fmt.Sprintf("Your project has been created but the %q provider can't start it", selectedProvider)
+		fmt.Fprintln(messages, styles.Warning.Render(errorMsg))
+		return
+	}
 
-		if err := runner.Run(); err != nil {
+	if err := runWithProgress(mode, provider, "Starting your sandbox", starter.StartProject); err != nil {
+		errorMsg := fmt.Sprintf("Error starting project: %q", err)
+		fmt.Fprintln(messages, styles.Error.Render(errorMsg))
+		return
+	}
+
+	printResult(mode, "Your sandbox is up and running")
+}
+
+// askQuestion asks the question until its answer is valid. It returns false
+// when the question couldn't be asked or the user cancelled it.
+func askQuestion(question *providers.Question) bool {
+	for {
+		if err := newQuestionRunner(question).Run(); err != nil {
 			errorMsg := fmt.Sprintf("Error Running Program: %q", err)
-			fmt.Println(styles.Error.Render(errorMsg))
-			return
+			fmt.Fprintln(messages, styles.Error.Render(errorMsg))
+			return false
 		}
 		if question.Answer == "" {
-			return
+			return false
 		}
+
+		if question.Validate == nil {
+			return true
+		}
+
+		err := question.Validate(question.Answer)
+		if err == nil {
+			return true
+		}
+		fmt.Fprintln(messages, styles.Error.Render(err.Error()))
 	}
+}
 
-	// Have the provider generate the project directory
-	if err := provider.GenerateProject(ddapikey); err != nil {
-		errorMsg := fmt.Sprintf("Error generating project: %q", err)
-		fmt.Println(styles.Error.Render(errorMsg))
+// printResult prints the success message, it is skipped for the JSON output
+// mode so that stdout only contains events.
+func printResult(mode string, msg string) {
+	if mode == jsonOutput {
 		return
 	}
 
-	fmt.Println(styles.Success.Render("Your project has been created"))
+	fmt.Fprintln(messages, styles.Success.Render(msg))
 }
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/aldrickdev/dbm-sandbox/internal/providers"
+	"github.com/aldrickdev/dbm-sandbox/internal/styles"
+
+	"github.com/spf13/cobra"
+)
+
+var templatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "Manage the templates used to generate the projects",
+}
+
+var templatesExportCmd = &cobra.Command{
+	Use:   "export <directory>",
+	Short: "Export the embedded templates",
+	Long:  `Export the embedded templates to a new directory, as a starting point for the directory passed with --templates-dir`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTemplatesExport,
+}
+
+func init() {
+	templatesCmd.AddCommand(templatesExportCmd)
+	rootCmd.AddCommand(templatesCmd)
+}
+
+func runTemplatesExport(cmd *cobra.Command, args []string) error {
+	if err := providers.ExportTemplates(args[0]); err != nil {
+		return err
+	}
+
+	fmt.Println(styles.Success.Render(fmt.Sprintf("The templates have been exported to %q", args[0])))
+	return nil
+}
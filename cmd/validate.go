@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/aldrickdev/dbm-sandbox/internal/styles"
+	"github.com/aldrickdev/dbm-sandbox/internal/utils/components/progress"
+	"github.com/aldrickdev/dbm-sandbox/internal/validate"
+
+	"github.com/spf13/cobra"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate <directory>",
+	Short: "Validate a generated Docker or Podman project",
+	Long:  `Validate the compose file of a generated Docker or Podman project against the compose specification, the agent configuration of its integrations, and that the agent uses the hosts and credentials the sandbox creates. Nothing is started.`,
+	Args:  cobra.ExactArgs(1),
+	// An invalid project isn't a usage error
+	SilenceUsage: true,
+	RunE:         runValidate,
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	problems := validate.Project(args[0])
+	if len(problems) == 0 {
+		fmt.Println(styles.Success.Render("The project is valid"))
+		return nil
+	}
+
+	for _, problem := range problems {
+		fmt.Printf("  %s %s\n", progress.Mark(progress.Failed), problem)
+	}
+
+	return fmt.Errorf("The project has %d problems", len(problems))
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aldrickdev/dbm-sandbox/internal/providers"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	// versionsDBMS limits the versions command to a single DBMS.
+	versionsDBMS string
+	// versionsOutput is how the versions are printed, plain or json.
+	versionsOutput string
+)
+
+var versionsCmd = &cobra.Command{
+	Use:   "versions",
+	Short: "List the supported agent and DBMS versions",
+	Long:  `List the agent versions, the versions of each DBMS and the providers each of them is available on`,
+	Args:  cobra.NoArgs,
+	RunE:  runVersions,
+}
+
+// agentVersion is an agent version and its description, as printed by the
+// versions command.
+type agentVersion struct {
+	Version string `json:"version"`
+	Note    string `json:"note,omitempty"`
+}
+
+// versionsReport is what the versions command prints.
+type versionsReport struct {
+	Agent []agentVersion          `json:"agent"`
+	DBMS  []providers.DBMSSupport `json:"dbms"`
+}
+
+func init() {
+	versionsCmd.Flags().StringVar(&versionsDBMS, "dbms", "", "only list the versions of this DBMS, for example postgres")
+	versionsCmd.Flags().StringVarP(&versionsOutput, "output", "o", plainOutput, fmt.Sprintf("how the versions are printed, one of: %s, %s", plainOutput, jsonOutput))
+
+	rootCmd.AddCommand(versionsCmd)
+}
+
+func runVersions(cmd *cobra.Command, args []string) error {
+	if versionsOutput != plainOutput && versionsOutput != jsonOutput {
+		return fmt.Errorf("Unknown output mode %q, expected one of: %q", versionsOutput, []string{plainOutput, jsonOutput})
+	}
+
+	report := versionsReport{Agent: []agentVersion{}}
+
+	versions, notes := providers.AgentVersions()
+	for ix, version := range versions {
+		report.Agent = append(report.Agent, agentVersion{Version: version, Note: notes[ix]})
+	}
+
+	report.DBMS = providers.GetDBMSSupport()
+	if versionsDBMS != "" {
+		dbms, err := filterDBMS(report.DBMS, versionsDBMS)
+		if err != nil {
+			return err
+		}
+		report.DBMS = dbms
+	}
+
+	if versionsOutput == jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	printVersions(os.Stdout, report)
+	return nil
+}
+
+// filterDBMS returns the DBMS with the name, ignoring the case and the
+// spaces so that "postgres" or "sqlserver" can be used.
+func filterDBMS(supports []providers.DBMSSupport, name string) ([]providers.DBMSSupport, error) {
+	names := []string{}
+	for _, support := range supports {
+		if normalizeDBMSName(support.Name) == normalizeDBMSName(name) {
+			return []providers.DBMSSupport{support}, nil
+		}
+		names = append(names, support.Name)
+	}
+
+	return nil, fmt.Errorf("Unknown DBMS %q, expected one of: %q", name, names)
+}
+
+// normalizeDBMSName lowercases the name and removes its spaces.
+func normalizeDBMSName(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), " ", "")
+}
+
+// printVersions prints the report as aligned columns.
+func printVersions(out io.Writer, report versionsReport) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "Agent")
+	for _, version := range report.Agent {
+		fmt.Fprintf(w, "  %s\t%s\n", version.Version, version.Note)
+	}
+
+	for _, dbms := range report.DBMS {
+		fmt.Fprintln(w)
+		if dbms.MinAgentVersion != "" {
+			fmt.Fprintf(w, "%s (requires agent %s+)\n", dbms.Name, dbms.MinAgentVersion)
+		} else {
+			fmt.Fprintln(w, dbms.Name)
+		}
+
+		for _, version := range dbms.Versions {
+			note := version.Note
+			if version.MinAgentVersion != "" {
+				note = strings.TrimPrefix(note+", requires agent "+version.MinAgentVersion+"+", ", ")
+			}
+			fmt.Fprintf(w, "  %s\t%s\t%s\n", version.Version, note, strings.Join(version.Providers, ", "))
+		}
+	}
+
+	w.Flush()
+}
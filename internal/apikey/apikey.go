@@ -0,0 +1,57 @@
+package apikey
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const (
+	// ENV is the environment variable the Datadog API Key is read from.
+	ENV = "DD_API_KEY"
+
+	// Placeholder is the dummy API Key used when the user only wants to inspect
+	// the generated files. It is intentionally not a valid API Key so that it
+	// can never be mistaken for a real one.
+	Placeholder = "PLACEHOLDER-NOT-A-REAL-API-KEY"
+)
+
+// keyFormat is the offline format of a Datadog API Key, 32 hex characters.
+var keyFormat = regexp.MustCompile(`^[0-9a-fA-F]{32}$`)
+
+// IsWellFormed reports whether the key looks like a Datadog API Key. This is
+// only an offline format check, it does not verify the key with Datadog.
+func IsWellFormed(key string) bool {
+	return keyFormat.MatchString(key)
+}
+
+// IsPlaceholder reports whether the key is the placeholder API Key.
+func IsPlaceholder(key string) bool {
+	return key == Placeholder
+}
+
+// FromEnv returns the API Key found in the ENV environment variable. The
+// boolean is false when the variable is unset or empty.
+func FromEnv() (string, bool) {
+	key, ok := os.LookupEnv(ENV)
+	key = strings.TrimSpace(key)
+
+	return key, ok && key != ""
+}
+
+// FromFile reads the API Key from the file at path. Surrounding whitespace is
+// ignored.
+func FromFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("Failed to read the API Key from %q, error: %q", path, err)
+	}
+
+	key := strings.TrimSpace(string(content))
+	if key == "" {
+		return "", fmt.Errorf("No API Key found in %q", path)
+	}
+
+	return key, nil
+}
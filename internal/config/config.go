@@ -0,0 +1,100 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// directoryName is the name of the directory, inside the users config
+	// directory, where the tool keeps its files.
+	directoryName = "dbm-sandbox"
+	// fileName is the name of the config file.
+	fileName = "config.yaml"
+	// catalogFileName is the name of the file that overrides the version
+	// catalog.
+	catalogFileName = "catalog.yaml"
+	// packsDirectoryName is the name of the directory the template packs are
+	// installed in.
+	packsDirectoryName = "packs"
+)
+
+// Config holds the user settings that can be set in the config file. Every
+// setting can be overridden using the flag with the same name.
+type Config struct {
+	// Theme is the name of the theme used to render the TUI.
+	Theme string `yaml:"theme"`
+	// ASCII replaces the emojis and the banner with plain ASCII text.
+	ASCII bool `yaml:"ascii"`
+	// TemplatesDir is a directory of templates that replace, or are added to,
+	// the embedded templates.
+	TemplatesDir string `yaml:"templates_dir"`
+}
+
+// Directory returns the directory where the tool keeps its files, for example
+// ~/.config/dbm-sandbox on Linux.
+func Directory() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("Failed to find the user config directory, error: %q", err)
+	}
+
+	return filepath.Join(configDir, directoryName), nil
+}
+
+// Path returns the location of the config file.
+func Path() (string, error) {
+	dir, err := Directory()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, fileName), nil
+}
+
+// CatalogPath returns the location of the file that overrides the version
+// catalog.
+func CatalogPath() (string, error) {
+	dir, err := Directory()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, catalogFileName), nil
+}
+
+// PacksPath returns the location of the directory the template packs are
+// installed in.
+func PacksPath() (string, error) {
+	dir, err := Directory()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, packsDirectoryName), nil
+}
+
+// Load reads the config file at path. A missing config file is not an error,
+// the default Config is returned instead.
+func Load(path string) (Config, error) {
+	var cfg Config
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("Failed to read the config file %q, error: %q", path, err)
+	}
+
+	if err := yaml.Unmarshal(content, &cfg); err != nil {
+		return cfg, fmt.Errorf("Failed to parse the config file %q, error: %q", path, err)
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,10 @@
+//go:build !linux && !darwin
+
+package doctor
+
+import "errors"
+
+// freeSpace isn't supported on this platform.
+func freeSpace(dir string) (uint64, error) {
+	return 0, errors.New("not supported on this platform")
+}
@@ -0,0 +1,293 @@
+package doctor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aldrickdev/dbm-sandbox/internal/apikey"
+)
+
+// A Status is the outcome of a check.
+type Status string
+
+const (
+	// Pass means nothing needs to be done.
+	Pass Status = "pass"
+	// Warn means the sandbox can be created but something may not work.
+	Warn Status = "warn"
+	// Fail means the sandbox can't be started until it is fixed.
+	Fail Status = "fail"
+)
+
+const (
+	// minDiskSpace is the free disk space below which the images can't be
+	// pulled.
+	minDiskSpace = 2 << 30
+	// recommendedDiskSpace is the free disk space needed to pull the images of
+	// every DBMS.
+	recommendedDiskSpace = 10 << 30
+)
+
+// A Result is the outcome of one check.
+type Result struct {
+	// Check is what was checked.
+	Check  string `json:"check"`
+	Status Status `json:"status"`
+	// Detail describes what was found.
+	Detail string `json:"detail"`
+	// Hint explains how to fix the problem, empty when the check passed.
+	Hint string `json:"hint,omitempty"`
+}
+
+// A Port is a port of the host the sandbox needs.
+type Port struct {
+	// Name describes what uses the port.
+	Name   string
+	Number int
+}
+
+// Environment is what the checks inspect, System returns the one of the
+// machine the tool runs on.
+type Environment struct {
+	// LookPath finds the executable in the PATH.
+	LookPath func(file string) (string, error)
+	// Run runs the command and returns its trimmed output.
+	Run func(name string, args ...string) (string, error)
+	// PortFree reports whether nothing listens on the port.
+	PortFree func(port int) bool
+	// FreeSpace returns the bytes available in the directory.
+	FreeSpace func(dir string) (uint64, error)
+	// LookupEnv returns the value of the environment variable.
+	LookupEnv func(key string) (string, bool)
+
+	// Dir is the directory the sandbox is created in.
+	Dir string
+	// Ports are the ports the sandbox needs.
+	Ports []Port
+}
+
+// An engine is a container engine the sandboxes can be started with.
+type engine struct {
+	name   string
+	binary string
+	// version, compose and daemon are the arguments of the commands that
+	// print the version of the CLI, of compose and of the daemon.
+	version []string
+	compose []string
+	daemon  []string
+	// composeHint and daemonHint explain how to fix a missing compose or an
+	// unreachable daemon.
+	composeHint string
+	daemonHint  string
+}
+
+var engines = []engine{
+	{
+		name:        "Docker",
+		binary:      "docker",
+		version:     []string{"--version"},
+		compose:     []string{"compose", "version", "--short"},
+		daemon:      []string{"info", "--format", "{{.ServerVersion}}"},
+		composeHint: "Install the Docker Compose plugin, it is included in Docker Desktop",
+		daemonHint:  "Start Docker Desktop, or the docker service with `sudo systemctl start docker`, and check that your user can access its socket",
+	},
+	{
+		name:        "Podman",
+		binary:      "podman",
+		version:     []string{"--version"},
+		compose:     []string{"compose", "version"},
+		daemon:      []string{"info", "--format", "{{.Version.Version}}"},
+		composeHint: "Install podman-compose or docker-compose, `podman compose` runs one of them",
+		daemonHint:  "Start the Podman machine with `podman machine start`, or the podman socket with `systemctl --user start podman.socket`",
+	},
+}
+
+// Run runs every check against the environment.
+func Run(env Environment) []Result {
+	results := checkEngines(env)
+	results = append(results, checkPorts(env)...)
+	results = append(results, checkDiskSpace(env), checkAPIKey(env))
+
+	return results
+}
+
+// Failed reports whether one of the results is a failure.
+func Failed(results []Result) bool {
+	for _, result := range results {
+		if result.Status == Fail {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkEngines checks the CLI, compose and the daemon of the container
+// engines that are installed. Only one of them is needed, the failures of the
+// others are only warnings when one of them works.
+func checkEngines(env Environment) []Result {
+	checked := [][]Result{}
+	working := ""
+	for _, e := range engines {
+		if _, err := env.LookPath(e.binary); err != nil {
+			continue
+		}
+
+		engineResults := e.check(env)
+		if working == "" && !Failed(engineResults) {
+			working = e.name
+		}
+		checked = append(checked, engineResults)
+	}
+
+	results := []Result{}
+	for _, engineResults := range checked {
+		for _, result := range engineResults {
+			if working != "" && result.Status == Fail {
+				result.Status = Warn
+				result.Detail = fmt.Sprintf("%s, %s can be used instead", result.Detail, working)
+			}
+			results = append(results, result)
+		}
+	}
+
+	if len(results) == 0 {
+		results = append(results, Result{
+			Check:  "Container engine",
+			Status: Fail,
+			Detail: "Neither docker nor podman were found in the PATH",
+			Hint:   "Install Docker Desktop, Docker Engine or Podman",
+		})
+	}
+
+	return results
+}
+
+// check checks the CLI, compose and the daemon of the engine.
+func (e engine) check(env Environment) []Result {
+	results := []Result{}
+
+	version, err := env.Run(e.binary, e.version...)
+	if err != nil {
+		return append(results, Result{
+			Check:  e.name,
+			Status: Fail,
+			Detail: fmt.Sprintf("%s was found but doesn't run: %s", e.binary, err),
+			Hint:   fmt.Sprintf("Reinstall %s", e.name),
+		})
+	}
+	results = append(results, Result{Check: e.name, Status: Pass, Detail: firstLine(version)})
+
+	compose := Result{Check: e.name + " Compose", Status: Pass}
+	if version, err := env.Run(e.binary, e.compose...); err != nil {
+		compose.Status = Fail
+		compose.Detail = fmt.Sprintf("`%s %s` doesn't work: %s", e.binary, strings.Join(e.compose[:1], " "), err)
+		compose.Hint = e.composeHint
+	} else {
+		compose.Detail = firstLine(version)
+	}
+	results = append(results, compose)
+
+	daemon := Result{Check: e.name + " daemon", Status: Pass}
+	if version, err := env.Run(e.binary, e.daemon...); err != nil {
+		daemon.Status = Fail
+		daemon.Detail = fmt.Sprintf("The %s daemon isn't reachable: %s", e.name, err)
+		daemon.Hint = e.daemonHint
+	} else {
+		daemon.Detail = fmt.Sprintf("Reachable, version %s", firstLine(version))
+	}
+
+	return append(results, daemon)
+}
+
+// checkPorts checks that nothing listens on the ports the sandbox needs.
+func checkPorts(env Environment) []Result {
+	results := []Result{}
+	for _, port := range env.Ports {
+		result := Result{
+			Check:  fmt.Sprintf("Port %d", port.Number),
+			Status: Pass,
+			Detail: fmt.Sprintf("Free, used by %s", port.Name),
+		}
+
+		if !env.PortFree(port.Number) {
+			result.Status = Warn
+			result.Detail = fmt.Sprintf("Already in use, needed by %s", port.Name)
+			result.Hint = fmt.Sprintf("Stop the service listening on port %d, otherwise the Dev Container forwards %s to another port", port.Number, port.Name)
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// checkDiskSpace checks that there is enough space to pull the images.
+func checkDiskSpace(env Environment) Result {
+	result := Result{Check: "Disk space", Status: Pass}
+
+	free, err := env.FreeSpace(env.Dir)
+	if err != nil {
+		result.Status = Warn
+		result.Detail = fmt.Sprintf("Failed to get the free disk space of %q, error: %q", env.Dir, err)
+		return result
+	}
+
+	result.Detail = fmt.Sprintf("%s free in %s", formatBytes(free), env.Dir)
+	switch {
+	case free < minDiskSpace:
+		result.Status = Fail
+		result.Hint = fmt.Sprintf("Free at least %s, the agent and DBMS images need it", formatBytes(minDiskSpace))
+
+	case free < recommendedDiskSpace:
+		result.Status = Warn
+		result.Hint = fmt.Sprintf("Free up to %s to pull the images of several sandboxes, `docker system prune` removes the unused ones", formatBytes(recommendedDiskSpace))
+	}
+
+	return result
+}
+
+// checkAPIKey checks that the Datadog API Key is set and well-formed, the key
+// itself is never reported.
+func checkAPIKey(env Environment) Result {
+	result := Result{Check: "Datadog API Key", Status: Pass}
+
+	key, ok := env.LookupEnv(apikey.ENV)
+	key = strings.TrimSpace(key)
+	switch {
+	case !ok || key == "":
+		result.Status = Warn
+		result.Detail = fmt.Sprintf("%s isn't set, the API Key will be asked for", apikey.ENV)
+		result.Hint = fmt.Sprintf("Export %s, or use --api-key-file", apikey.ENV)
+
+	case apikey.IsPlaceholder(key):
+		result.Status = Warn
+		result.Detail = fmt.Sprintf("%s is the placeholder API Key, the agent won't send data to Datadog", apikey.ENV)
+		result.Hint = fmt.Sprintf("Set %s to an API Key of your organization", apikey.ENV)
+
+	case !apikey.IsWellFormed(key):
+		result.Status = Fail
+		result.Detail = fmt.Sprintf("%s doesn't look like a Datadog API Key (32 hex characters)", apikey.ENV)
+		result.Hint = "Copy an API Key from Organization Settings > API Keys, application keys are longer"
+
+	default:
+		result.Detail = fmt.Sprintf("%s is set and well-formed", apikey.ENV)
+	}
+
+	return result
+}
+
+// firstLine returns the first line of the output, trimmed.
+func firstLine(output string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(output), "\n")
+	return strings.TrimSpace(line)
+}
+
+// formatBytes formats the size in GB, or MB below 1 GB.
+func formatBytes(size uint64) string {
+	if size < 1<<30 {
+		return fmt.Sprintf("%d MB", size>>20)
+	}
+
+	return fmt.Sprintf("%.1f GB", float64(size)/(1<<30))
+}
@@ -0,0 +1,205 @@
+package doctor
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// fakeEnvironment returns an environment where docker is installed and
+// working, the ports are free, there is plenty of disk space and a valid API
+// Key is set.
+func fakeEnvironment() Environment {
+	return Environment{
+		LookPath: func(file string) (string, error) {
+			if file == "docker" {
+				return "/usr/bin/docker", nil
+			}
+			return "", errors.New("not found")
+		},
+		Run: func(name string, args ...string) (string, error) {
+			return "27.0.3\n", nil
+		},
+		PortFree:  func(port int) bool { return true },
+		FreeSpace: func(dir string) (uint64, error) { return 50 << 30, nil },
+		LookupEnv: func(key string) (string, bool) { return "0123456789abcdef0123456789abcdef", true },
+		Dir:       "/sandboxes",
+		Ports:     []Port{{Name: "Postgres", Number: 5432}},
+	}
+}
+
+// statuses returns the status of each result, by check.
+func statuses(results []Result) map[string]Status {
+	found := map[string]Status{}
+	for _, result := range results {
+		found[result.Check] = result.Status
+	}
+
+	return found
+}
+
+func TestRunPasses(t *testing.T) {
+	results := Run(fakeEnvironment())
+
+	expected := []string{"Docker", "Docker Compose", "Docker daemon", "Port 5432", "Disk space", "Datadog API Key"}
+	if len(results) != len(expected) {
+		t.Fatalf("got %d results, expected %d: %v", len(results), len(expected), results)
+	}
+
+	for ix, result := range results {
+		if result.Check != expected[ix] {
+			t.Errorf("result %d is %q, expected %q", ix, result.Check, expected[ix])
+		}
+		if result.Status != Pass || result.Hint != "" {
+			t.Errorf("%q is %s with the hint %q, expected it to pass", result.Check, result.Status, result.Hint)
+		}
+	}
+
+	if Failed(results) {
+		t.Error("the results are reported as failed")
+	}
+}
+
+func TestRunReportsProblems(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(env *Environment)
+		check  string
+		status Status
+	}{
+		{
+			name: "no engine",
+			change: func(env *Environment) {
+				env.LookPath = func(string) (string, error) { return "", errors.New("not found") }
+			},
+			check:  "Container engine",
+			status: Fail,
+		},
+		{
+			name: "daemon unreachable",
+			change: func(env *Environment) {
+				env.Run = func(name string, args ...string) (string, error) {
+					if args[0] == "info" {
+						return "", errors.New("Cannot connect to the Docker daemon")
+					}
+					return "27.0.3", nil
+				}
+			},
+			check:  "Docker daemon",
+			status: Fail,
+		},
+		{
+			name: "compose missing",
+			change: func(env *Environment) {
+				env.Run = func(name string, args ...string) (string, error) {
+					if args[0] == "compose" {
+						return "", errors.New("unknown command")
+					}
+					return "27.0.3", nil
+				}
+			},
+			check:  "Docker Compose",
+			status: Fail,
+		},
+		{
+			name:   "port in use",
+			change: func(env *Environment) { env.PortFree = func(int) bool { return false } },
+			check:  "Port 5432",
+			status: Warn,
+		},
+		{
+			name:   "low disk space",
+			change: func(env *Environment) { env.FreeSpace = func(string) (uint64, error) { return 5 << 30, nil } },
+			check:  "Disk space",
+			status: Warn,
+		},
+		{
+			name:   "no disk space",
+			change: func(env *Environment) { env.FreeSpace = func(string) (uint64, error) { return 500 << 20, nil } },
+			check:  "Disk space",
+			status: Fail,
+		},
+		{
+			name:   "API Key unset",
+			change: func(env *Environment) { env.LookupEnv = func(string) (string, bool) { return "", false } },
+			check:  "Datadog API Key",
+			status: Warn,
+		},
+		{
+			name:   "API Key malformed",
+			change: func(env *Environment) { env.LookupEnv = func(string) (string, bool) { return "not-a-key", true } },
+			check:  "Datadog API Key",
+			status: Fail,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			env := fakeEnvironment()
+			test.change(&env)
+
+			results := Run(env)
+			if status := statuses(results)[test.check]; status != test.status {
+				t.Fatalf("%q is %q, expected %q", test.check, status, test.status)
+			}
+
+			for _, result := range results {
+				if result.Check == test.check && result.Hint == "" {
+					t.Errorf("%q has no hint", test.check)
+				}
+			}
+
+			if Failed(results) != (test.status == Fail) {
+				t.Errorf("Failed is %t, expected %t", Failed(results), test.status == Fail)
+			}
+		})
+	}
+}
+
+func TestOnlyOneEngineIsNeeded(t *testing.T) {
+	env := fakeEnvironment()
+	env.LookPath = func(file string) (string, error) { return "/usr/bin/" + file, nil }
+
+	// The Podman machine is stopped
+	env.Run = func(name string, args ...string) (string, error) {
+		if name == "podman" && args[0] == "info" {
+			return "", errors.New("Cannot connect to Podman")
+		}
+		return "5.2.0", nil
+	}
+
+	results := Run(env)
+	found := statuses(results)
+	if found["Docker daemon"] != Pass || found["Podman daemon"] != Warn {
+		t.Errorf("the Docker daemon is %q and the Podman one %q, expected %q and %q", found["Docker daemon"], found["Podman daemon"], Pass, Warn)
+	}
+	if Failed(results) {
+		t.Error("the results are reported as failed while Docker works")
+	}
+
+	// Neither of the daemons is reachable
+	env.Run = func(name string, args ...string) (string, error) {
+		if args[0] == "info" {
+			return "", errors.New("Cannot connect")
+		}
+		return "5.2.0", nil
+	}
+
+	results = Run(env)
+	found = statuses(results)
+	if found["Docker daemon"] != Fail || found["Podman daemon"] != Fail {
+		t.Errorf("the Docker daemon is %q and the Podman one %q, expected both to fail", found["Docker daemon"], found["Podman daemon"])
+	}
+}
+
+func TestAPIKeyIsNeverReported(t *testing.T) {
+	key := "0123456789abcdef0123456789abcdeX"
+	env := fakeEnvironment()
+	env.LookupEnv = func(string) (string, bool) { return key, true }
+
+	for _, result := range Run(env) {
+		if strings.Contains(result.Detail+result.Hint, key) {
+			t.Errorf("%q reports the API Key", result.Check)
+		}
+	}
+}
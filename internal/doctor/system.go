@@ -0,0 +1,66 @@
+package doctor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// commandTimeout is how long a command can run, the CLIs hang for a while
+// when their daemon isn't reachable.
+const commandTimeout = 15 * time.Second
+
+// System returns the environment of the machine the tool runs on.
+func System(dir string, ports []Port) Environment {
+	return Environment{
+		LookPath:  exec.LookPath,
+		Run:       runCommand,
+		PortFree:  portFree,
+		FreeSpace: freeSpace,
+		LookupEnv: os.LookupEnv,
+		Dir:       dir,
+		Ports:     ports,
+	}
+}
+
+// runCommand runs the command and returns its trimmed output, the error
+// includes what the command printed to stderr.
+func runCommand(name string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	command := exec.CommandContext(ctx, name, args...)
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+
+	if err := command.Run(); err != nil {
+		if ctx.Err() != nil {
+			return "", fmt.Errorf("timed out after %s", commandTimeout)
+		}
+
+		if output := firstLine(stderr.String()); output != "" {
+			return "", fmt.Errorf("%s", output)
+		}
+		return "", err
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// portFree reports whether the port of the loopback interface can be
+// listened on.
+func portFree(port int) bool {
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		return false
+	}
+
+	listener.Close()
+	return true
+}
@@ -0,0 +1,220 @@
+package packs
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// gzipMagic are the first bytes of a gzip compressed file.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// Install installs the pack found at the source, either a directory or a
+// tarball, optionally gzip compressed, into the packs directory. An installed
+// pack with the same name is only replaced when force is true.
+func Install(source string, dir string, force bool) (Pack, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return Pack{}, fmt.Errorf("Failed to find the pack %q, error: %q", source, err)
+	}
+
+	packDir := source
+	if !info.IsDir() {
+		extracted, err := os.MkdirTemp("", "dbm-sandbox-pack-")
+		if err != nil {
+			return Pack{}, fmt.Errorf("Failed to create a temporary directory, error: %q", err)
+		}
+		defer os.RemoveAll(extracted)
+
+		if err := extractTarball(source, extracted); err != nil {
+			return Pack{}, err
+		}
+
+		packDir, err = findManifest(extracted)
+		if err != nil {
+			return Pack{}, fmt.Errorf("Failed to find the pack in %q, error: %q", source, err)
+		}
+	}
+
+	pack, err := Load(packDir)
+	if err != nil {
+		return pack, err
+	}
+
+	destination := filepath.Join(dir, pack.Name)
+	if sameDirectory(packDir, destination) {
+		return pack, fmt.Errorf("The pack %q is already installed from %q", pack.Name, source)
+	}
+	if _, err := os.Stat(destination); err == nil {
+		if !force {
+			return pack, fmt.Errorf("The pack %q is already installed, use --force to replace it", pack.Name)
+		}
+
+		if err := os.RemoveAll(destination); err != nil {
+			return pack, fmt.Errorf("Failed to remove the installed pack %q, error: %q", destination, err)
+		}
+	}
+
+	if err := copyTree(packDir, destination); err != nil {
+		return pack, err
+	}
+
+	pack.Dir = destination
+	return pack, nil
+}
+
+// sameDirectory reports whether both paths are the same directory.
+func sameDirectory(a string, b string) bool {
+	aInfo, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+
+	bInfo, err := os.Stat(b)
+	if err != nil {
+		return false
+	}
+
+	return os.SameFile(aInfo, bInfo)
+}
+
+// findManifest returns the directory of the pack in the extracted tarball,
+// which is either its root or its only top level directory.
+func findManifest(root string) (string, error) {
+	if _, err := os.Stat(filepath.Join(root, ManifestName)); err == nil {
+		return root, nil
+	}
+
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return "", err
+	}
+
+	if len(entries) == 1 && entries[0].IsDir() {
+		dir := filepath.Join(root, entries[0].Name())
+		if _, err := os.Stat(filepath.Join(dir, ManifestName)); err == nil {
+			return dir, nil
+		}
+	}
+
+	return "", fmt.Errorf("no %s found at the root or in a single top level directory", ManifestName)
+}
+
+// extractTarball extracts the regular files and directories of the tarball
+// into the destination. Entries that would be written outside of it are
+// rejected.
+func extractTarball(source string, destination string) error {
+	file, err := os.Open(source)
+	if err != nil {
+		return fmt.Errorf("Failed to open the pack %q, error: %q", source, err)
+	}
+	defer file.Close()
+
+	buffered := bufio.NewReader(file)
+	var reader io.Reader = buffered
+	magic, err := buffered.Peek(len(gzipMagic))
+	if err == nil && bytes.Equal(magic, gzipMagic) {
+		gz, err := gzip.NewReader(reader)
+		if err != nil {
+			return fmt.Errorf("Failed to decompress the pack %q, error: %q", source, err)
+		}
+		defer gz.Close()
+		reader = gz
+	}
+
+	archive := tar.NewReader(reader)
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("Failed to read the pack %q, error: %q", source, err)
+		}
+
+		name := filepath.FromSlash(strings.TrimPrefix(header.Name, "./"))
+		if name == "" || name == "." {
+			continue
+		}
+		if !filepath.IsLocal(name) {
+			return fmt.Errorf("The pack %q contains a file outside of its directory: %q", source, header.Name)
+		}
+		target := filepath.Join(destination, name)
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return fmt.Errorf("Failed to create the directory %q, error: %q", target, err)
+			}
+
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return fmt.Errorf("Failed to create the directory %q, error: %q", filepath.Dir(target), err)
+			}
+			if err := writeFile(target, archive); err != nil {
+				return err
+			}
+
+		default:
+			return fmt.Errorf("The pack %q contains %q which is not a regular file or a directory", source, header.Name)
+		}
+	}
+}
+
+// copyTree copies the regular files and directories of the source directory
+// into the destination.
+func copyTree(source string, destination string) error {
+	return filepath.WalkDir(source, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relative, err := filepath.Rel(source, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(destination, relative)
+
+		if entry.IsDir() {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return fmt.Errorf("Failed to create the directory %q, error: %q", target, err)
+			}
+			return nil
+		}
+
+		if !entry.Type().IsRegular() {
+			return fmt.Errorf("The pack contains %q which is not a regular file or a directory", path)
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("Failed to open %q, error: %q", path, err)
+		}
+		defer file.Close()
+
+		return writeFile(target, file)
+	})
+}
+
+// writeFile writes the content of the reader to the file.
+func writeFile(name string, content io.Reader) error {
+	file, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("Failed to create the file %q, error: %q", name, err)
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, content); err != nil {
+		return fmt.Errorf("Failed to write to file: %q, error: %q", name, err)
+	}
+
+	return nil
+}
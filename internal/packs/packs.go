@@ -0,0 +1,218 @@
+package packs
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ManifestName is the name of the manifest of a pack.
+	ManifestName = "pack.yaml"
+	// TemplatesDirectory is the directory of a pack with its template
+	// overlays.
+	TemplatesDirectory = "templates"
+	// FilesDirectory is the directory of a pack with the files rendered into
+	// the project.
+	FilesDirectory = "files"
+
+	// Picker and Input are the types of the questions of a pack.
+	Picker = "picker"
+	Input  = "input"
+)
+
+var (
+	// validName matches the names of the packs, which are also the name of the
+	// directory they are installed in.
+	validName = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+	// validQuestionName matches the names of the questions, which are used to
+	// find their answer in the templates.
+	validQuestionName = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`)
+)
+
+// A Pack is a template pack: a named and versioned bundle of template
+// overlays, extra questions and files that set up a scenario in a sandbox,
+// for example a Postgres database with bloated tables. A pack is a directory
+// with the following layout:
+//
+//	pack.yaml    the manifest, which is decoded into the Pack
+//	templates/   files that replace, or are added to, the embedded templates,
+//	             with the same layout as the directory passed with
+//	             --templates-dir
+//	files/       templates rendered into the project directory, the .tmpl
+//	             suffix is removed from their name
+type Pack struct {
+	// Name identifies the pack, for example pg-high-bloat.
+	Name string `yaml:"name"`
+	// Version is the version of the pack, for example 1.2.0.
+	Version string `yaml:"version"`
+	// Description is shown next to the Name when the user picks a pack.
+	Description string `yaml:"description"`
+	// DBMS are the DBMS's the pack can be used with, any DBMS when empty.
+	DBMS []string `yaml:"dbms"`
+	// Questions are asked after the pack is picked, their answers are
+	// available to the templates.
+	Questions []Question `yaml:"questions"`
+
+	// Dir is the directory the pack was loaded from.
+	Dir string `yaml:"-"`
+}
+
+// A Question is an extra question asked by a pack.
+type Question struct {
+	// Name is the key of the answer in the templates, for example
+	// {{ .Pack.Answers.table_count }}.
+	Name string `yaml:"name"`
+	// Prompt is the question presented to the user.
+	Prompt string `yaml:"prompt"`
+	// Type is either picker or input, input when empty.
+	Type string `yaml:"type"`
+	// Options are the options of a picker.
+	Options []string `yaml:"options"`
+	// Default is the default answer, it is required.
+	Default string `yaml:"default"`
+}
+
+// Load reads and validates the pack found in the directory.
+func Load(dir string) (Pack, error) {
+	var pack Pack
+
+	manifest := filepath.Join(dir, ManifestName)
+	content, err := os.ReadFile(manifest)
+	if err != nil {
+		return pack, fmt.Errorf("Failed to read the pack manifest %q, error: %q", manifest, err)
+	}
+
+	if err := yaml.Unmarshal(content, &pack); err != nil {
+		return pack, fmt.Errorf("Failed to parse the pack manifest %q, error: %q", manifest, err)
+	}
+
+	if err := pack.validate(); err != nil {
+		return pack, fmt.Errorf("Invalid pack manifest %q: %s", manifest, err)
+	}
+
+	pack.Dir = dir
+	return pack, nil
+}
+
+// List returns the packs installed in the directory, sorted by name. The
+// packs that can't be loaded are skipped and their errors returned, a missing
+// directory means no pack is installed.
+func List(dir string) ([]Pack, []error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, []error{fmt.Errorf("Failed to read the packs directory %q, error: %q", dir, err)}
+	}
+
+	installed := []Pack{}
+	errs := []error{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		pack, err := Load(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		installed = append(installed, pack)
+	}
+
+	sort.Slice(installed, func(i, j int) bool { return installed[i].Name < installed[j].Name })
+	return installed, errs
+}
+
+// Supports reports whether the pack can be used with the DBMS.
+func (p Pack) Supports(DBMSName string) bool {
+	if len(p.DBMS) == 0 {
+		return true
+	}
+
+	for _, name := range p.DBMS {
+		if name == DBMSName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TemplatesFS returns the template overlays of the pack.
+func (p Pack) TemplatesFS() fs.FS {
+	return os.DirFS(filepath.Join(p.Dir, TemplatesDirectory))
+}
+
+// FilesFS returns the files of the pack rendered into the project.
+func (p Pack) FilesFS() fs.FS {
+	return os.DirFS(filepath.Join(p.Dir, FilesDirectory))
+}
+
+// validate checks the manifest of the pack.
+func (p Pack) validate() error {
+	if !validName.MatchString(p.Name) {
+		return fmt.Errorf("the name %q must only contain lowercase letters, digits and dashes", p.Name)
+	}
+
+	if p.Version == "" {
+		return fmt.Errorf("the pack %q has no version", p.Name)
+	}
+
+	if p.Description == "" {
+		return fmt.Errorf("the pack %q has no description", p.Name)
+	}
+
+	seen := map[string]bool{}
+	for _, question := range p.Questions {
+		if err := question.validate(); err != nil {
+			return err
+		}
+
+		if seen[question.Name] {
+			return fmt.Errorf("the question %q is defined more than once", question.Name)
+		}
+		seen[question.Name] = true
+	}
+
+	return nil
+}
+
+// validate checks that the question can be asked.
+func (q Question) validate() error {
+	if !validQuestionName.MatchString(q.Name) {
+		return fmt.Errorf("the question name %q must start with a letter and only contain letters, digits and underscores", q.Name)
+	}
+
+	if q.Prompt == "" {
+		return fmt.Errorf("the question %q has no prompt", q.Name)
+	}
+
+	if q.Default == "" {
+		return fmt.Errorf("the question %q has no default", q.Name)
+	}
+
+	switch q.Type {
+	case "", Input:
+		return nil
+
+	case Picker:
+		for _, option := range q.Options {
+			if option == q.Default {
+				return nil
+			}
+		}
+		return fmt.Errorf("the default of the question %q must be one of its options", q.Name)
+
+	default:
+		return fmt.Errorf("the question %q has an unknown type %q, expected one of: %q", q.Name, q.Type, []string{Picker, Input})
+	}
+}
@@ -0,0 +1,152 @@
+package packs
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const manifest = `name: pg-high-bloat
+version: 1.0.0
+description: Tables with a lot of dead tuples
+dbms: [Postgres]
+questions:
+- name: table_count
+  prompt: How many bloated tables?
+  default: "10"
+`
+
+func TestLoadValidatesTheManifest(t *testing.T) {
+	pack, err := Load(writePack(t, t.TempDir(), manifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pack.Name != "pg-high-bloat" || len(pack.Questions) != 1 {
+		t.Errorf("unexpected pack: %+v", pack)
+	}
+	if !pack.Supports("Postgres") || pack.Supports("MySQL") {
+		t.Error("the pack should only support Postgres")
+	}
+
+	invalid := map[string]string{
+		"bad name":       strings.Replace(manifest, "pg-high-bloat", "PG High Bloat", 1),
+		"no version":     strings.Replace(manifest, "version: 1.0.0", "", 1),
+		"no description": strings.Replace(manifest, "description: Tables with a lot of dead tuples", "", 1),
+		"no default":     strings.Replace(manifest, `default: "10"`, "", 1),
+		"bad type":       manifest + "  type: slider\n",
+		"bad default":    manifest + "  type: picker\n  options: [\"1\", \"2\"]\n",
+		"duplicate":      manifest + "- name: table_count\n  prompt: Again?\n  default: \"1\"\n",
+	}
+	for name, content := range invalid {
+		if _, err := Load(writePack(t, t.TempDir(), content)); err == nil {
+			t.Errorf("expected the %s manifest to be rejected", name)
+		}
+	}
+}
+
+func TestInstallFromADirectory(t *testing.T) {
+	source := writePack(t, t.TempDir(), manifest)
+	packsDir := t.TempDir()
+
+	pack, err := Install(source, packsDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pack.Dir != filepath.Join(packsDir, "pg-high-bloat") {
+		t.Errorf("the pack was installed in %q", pack.Dir)
+	}
+	if _, err := os.Stat(filepath.Join(pack.Dir, FilesDirectory, "bloat.sql.tmpl")); err != nil {
+		t.Errorf("the files of the pack weren't copied: %s", err)
+	}
+
+	if _, err := Install(source, packsDir, false); err == nil {
+		t.Error("expected an error when the pack is already installed")
+	}
+	if _, err := Install(source, packsDir, true); err != nil {
+		t.Errorf("expected --force to replace the pack, got: %s", err)
+	}
+
+	installed, errs := List(packsDir)
+	if len(errs) != 0 || len(installed) != 1 || installed[0].Name != "pg-high-bloat" {
+		t.Errorf("unexpected installed packs: %+v, errors: %v", installed, errs)
+	}
+}
+
+func TestInstallFromATarball(t *testing.T) {
+	tarball := filepath.Join(t.TempDir(), "pack.tgz")
+	writeTarball(t, tarball, map[string]string{
+		"pg-high-bloat/pack.yaml":                manifest,
+		"pg-high-bloat/files/bloat.sql.tmpl":     "SELECT 1;\n",
+		"pg-high-bloat/templates/docker/NOTE.md": "note\n",
+	})
+
+	pack, err := Install(tarball, t.TempDir(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(pack.Dir, TemplatesDirectory, "docker", "NOTE.md")); err != nil {
+		t.Errorf("the templates of the pack weren't extracted: %s", err)
+	}
+
+	unsafe := filepath.Join(t.TempDir(), "unsafe.tgz")
+	writeTarball(t, unsafe, map[string]string{
+		"pack.yaml":     manifest,
+		"../escape.txt": "outside\n",
+	})
+	if _, err := Install(unsafe, t.TempDir(), false); err == nil {
+		t.Error("expected a tarball with a file outside of its directory to be rejected")
+	}
+}
+
+// writePack writes a pack with the manifest and a file into the directory.
+func writePack(t *testing.T, dir string, content string) string {
+	t.Helper()
+
+	files := map[string]string{
+		ManifestName: content,
+		filepath.Join(FilesDirectory, "bloat.sql.tmpl"): "SELECT 1;\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+// writeTarball writes a gzip compressed tarball with the files.
+func writeTarball(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+
+	var buffer bytes.Buffer
+	gz := gzip.NewWriter(&buffer)
+	archive := tar.NewWriter(gz)
+	for name, content := range files {
+		header := &tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if err := archive.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := archive.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, buffer.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,10 +1,27 @@
 package providers
 
-// https://hub.docker.com/r/datadog/agent/tags
-var (
-  // AgentVersions are a slices of the latest major versions of the Datadog
-  // Agent. This should be used to set the Options field when creating a 
-  // Question with QType, Picker.
-  AgentVersions = []string{"latest", "7.54.0", "7.53.0", "7.52.0"}
-)
+import "strings"
 
+// describeOptions returns a description for each of the options using the
+// notes, options without a note get an empty description.
+func describeOptions(options []string, notes map[string]string) []string {
+  descriptions := []string{}
+  for _, option := range options {
+    descriptions = append(descriptions, notes[option])
+  }
+
+  return descriptions
+}
+
+
+// joinNotes joins the notes shown next to an option, skipping the empty ones.
+func joinNotes(notes ...string) string {
+  joined := []string{}
+  for _, note := range notes {
+    if note != "" {
+      joined = append(joined, note)
+    }
+  }
+
+  return strings.Join(joined, ", ")
+}
@@ -0,0 +1,275 @@
+package providers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	AURORA = "Aurora"
+
+	// maxAuroraReaders is the number of readers an Aurora cluster can have.
+	maxAuroraReaders = 15
+
+	// The ways the agent can find the instances of the cluster.
+	auroraAutodiscovery = "Autodiscovery"
+	auroraExplicit      = "Explicit instances"
+)
+
+var (
+	// The indexes of where the Aurora specific provider questions can be
+	// found, set when the questions are generated.
+	AuroraReadersIndex   uint8
+	AuroraDiscoveryIndex uint8
+
+	// auroraInstanceClasses are the instance classes the cluster instances can
+	// use, Aurora doesn't support the smallest RDS instance classes.
+	auroraInstanceClasses = []string{"db.t3.medium", "db.t4g.medium", "db.r6g.large"}
+	// auroraInstanceClassNotes holds the descriptions shown next to some of
+	// the auroraInstanceClasses.
+	auroraInstanceClassNotes = map[string]string{
+		"db.t3.medium": "cheapest",
+		"db.r6g.large": "recommended",
+	}
+)
+
+// AuroraProvider implements the Provider Interface and generates a Terraform
+// project that creates an Amazon Aurora cluster, with a writer and readers,
+// and an EC2 instance running the Datadog Agent. It shares most of its
+// templates and questions with the RDSProvider.
+type AuroraProvider struct {
+	*RDSProvider
+
+	// auroraTemplateData is the data required to fill the project template
+	// files for this provider.
+	auroraTemplateData auroraTemplateData
+}
+
+// auroraTemplateData is used to contain the data for the AuroraProvider
+// template files.
+type auroraTemplateData struct {
+	rdsTemplateData
+
+	// Readers is the number of reader instances of the cluster.
+	Readers int
+	// Autodiscovery is true when the agent finds the instances of the cluster
+	// using Aurora autodiscovery, otherwise every instance is listed in the
+	// check configuration.
+	Autodiscovery bool
+}
+
+// GetAuroraProvider will initiallize a new AuroraProvider instance and return
+// a pointer to it.
+func GetAuroraProvider() *AuroraProvider {
+	ap := &AuroraProvider{
+		RDSProvider: &RDSProvider{
+			supportedDBMS:    []string{postgres, mysql},
+			templatePath:     "embed/terraform/aurora/",
+			awsTemplatePath:  "embed/terraform/aws/",
+			dbmsTemplatePath: "embed/docker/",
+			templateFS:       templateFS,
+		},
+	}
+	ap.dbmsLookup = auroraDBMS
+	ap.generateProviderQuestions()
+
+	return ap
+}
+
+// auroraDBMS returns the DBMS with the engine versions supported by Aurora,
+// which don't match the versions of the container images.
+//
+// Supported Versions: https://docs.aws.amazon.com/AmazonRDS/latest/AuroraUserGuide/Concepts.Aurora_Fea_Regions_DB-eng.Feature.html
+func auroraDBMS(DBMSName string) DBMS {
+	switch DBMSName {
+	case mysql:
+		return newDBMS(mysql, []string{"8.0.mysql_aurora.3.07.1", "8.0.mysql_aurora.3.05.2"}, map[string]string{
+			"8.0.mysql_aurora.3.07.1": "recommended",
+		})
+
+	default:
+		return newDBMS(postgres, []string{"16.4", "15.8", "14.13", "13.16"}, map[string]string{
+			"16.4": "recommended",
+		})
+	}
+}
+
+// generateProviderQuestions generates all of the questions that are required
+// to fill the providers template data.
+func (a *AuroraProvider) generateProviderQuestions() {
+	a.addCommonQuestions(a.supportedDBMS)
+	a.addAWSQuestions(auroraInstanceClasses, auroraInstanceClassNotes, "db.t3.medium")
+
+	readers := func() *Question {
+		question := &Question{
+			QType:         Input,
+			Prompt:        fmt.Sprintf("How many reader instances would you like? (0-%d)", maxAuroraReaders),
+			DefaultAnswer: "1",
+		}
+		a.QuestionAnswers = append(a.QuestionAnswers, question)
+
+		return question
+	}
+
+	discovery := func() *Question {
+		question := &Question{
+			QType:   Picker,
+			Prompt:  "How should the agent find the instances of the cluster?",
+			Options: []string{auroraAutodiscovery, auroraExplicit},
+			OptionDescriptions: []string{
+				"The agent discovers the instances using the AWS API",
+				"Every instance endpoint is listed in the check configuration",
+			},
+			DefaultAnswer: auroraAutodiscovery,
+		}
+		a.QuestionAnswers = append(a.QuestionAnswers, question)
+
+		return question
+	}
+
+	a.addQuestion(readers, &AuroraReadersIndex)
+	a.addQuestion(discovery, &AuroraDiscoveryIndex)
+}
+
+// fillTemplateData will fill the AuroraProvider.auroraTemplateData with the
+// answers, the DBMS init SQL and the agent configuration.
+func (a *AuroraProvider) fillTemplateData(ddapikey string) error {
+	readers, err := strconv.Atoi(a.answer(AuroraReadersIndex))
+	if err != nil || readers < 0 || readers > maxAuroraReaders {
+		return fmt.Errorf("The number of readers must be between 0 and %d, got: %q", maxAuroraReaders, a.answer(AuroraReadersIndex))
+	}
+
+	base, err := a.baseTemplateData(ddapikey)
+	if err != nil {
+		return err
+	}
+
+	data := auroraTemplateData{
+		rdsTemplateData: base,
+		Readers:         readers,
+		Autodiscovery:   a.answer(AuroraDiscoveryIndex) == auroraAutodiscovery,
+	}
+	data.Engine = auroraEngine(data.DB.DBMS)
+	data.ParameterGroupFamily = auroraParameterGroupFamily(data.DB.DBMS, data.DB.Version)
+
+	if data.Autodiscovery {
+		err = a.autodiscoveryConf(&data)
+	} else {
+		err = a.explicitConf(&data)
+	}
+	if err != nil {
+		return err
+	}
+
+	a.auroraTemplateData = data
+	return nil
+}
+
+// autodiscoveryConf sets the agent configuration used with Aurora
+// autodiscovery: a check template filled by the agent for every instance of
+// the clusters tagged with datadoghq.com/scrape:true.
+func (a *AuroraProvider) autodiscoveryConf(data *auroraTemplateData) error {
+	dbmsName := data.DB.DBMS
+
+	document, err := cloudAgentDocument(a.templateFS, a.dbmsTemplatePath, dbmsName, []agentInstance{{
+		Host:          "%%host%%",
+		Port:          "%%port%%",
+		Cloud:         "aws",
+		CloudSettings: [][2]string{{"instance_endpoint", "%%host%%"}, {"region", "%%extra_region%%"}},
+		Tags:          []string{"dbclusteridentifier:%%extra_dbclusteridentifier%%", "region:%%extra_region%%"},
+	}})
+	if err != nil {
+		return err
+	}
+
+	identifiers := &yaml.Node{Kind: yaml.SequenceNode}
+	identifiers.Content = append(identifiers.Content, scalarNode("_dbm_"+agentCheckName(dbmsName)+"_aurora"))
+	root := document.Content[0]
+	root.Content = append([]*yaml.Node{scalarNode("ad_identifiers"), identifiers}, root.Content...)
+
+	data.AgentConf, err = encodeYAML(document, dbmsName)
+	if err != nil {
+		return err
+	}
+
+	data.AgentConfFile = "conf_aws_aurora.yaml"
+	data.AgentDatadogConfig = "database_monitoring:\n  autodiscovery:\n    aurora:\n      enabled: true\n"
+
+	return nil
+}
+
+// explicitConf sets the agent configuration with an instance for the writer
+// and each of the readers, using the instance endpoints passed to the user
+// data by Terraform.
+func (a *AuroraProvider) explicitConf(data *auroraTemplateData) error {
+	instances := []agentInstance{}
+	for ix := 0; ix <= data.Readers; ix++ {
+		host := fmt.Sprintf("${instance_endpoints[%d]}", ix)
+
+		instances = append(instances, agentInstance{
+			Host:          host,
+			Cloud:         "aws",
+			CloudSettings: [][2]string{{"instance_endpoint", host}, {"region", data.Region}},
+			Tags: []string{
+				"dbclusteridentifier:" + data.Identifier,
+				"dbinstanceidentifier:" + auroraInstanceIdentifier(data.Identifier, ix),
+			},
+		})
+	}
+
+	var err error
+	data.AgentConf, err = cloudAgentConf(a.templateFS, a.dbmsTemplatePath, data.DB.DBMS, instances)
+
+	return err
+}
+
+// auroraInstanceIdentifier returns the identifier of the instance of the
+// cluster, the first instance is the writer and the others are the readers.
+func auroraInstanceIdentifier(cluster string, index int) string {
+	if index == 0 {
+		return cluster + "-writer"
+	}
+
+	return fmt.Sprintf("%s-reader-%d", cluster, index)
+}
+
+// auroraEngine returns the name of the Aurora engine of the DBMS.
+func auroraEngine(DBMSName string) string {
+	switch DBMSName {
+	case mysql:
+		return "aurora-mysql"
+
+	default:
+		return "aurora-postgresql"
+	}
+}
+
+// auroraParameterGroupFamily returns the cluster parameter group family of
+// the engine, which uses the major version for Postgres and the major and
+// minor versions for MySQL.
+func auroraParameterGroupFamily(DBMSName string, version string) string {
+	parts := strings.Split(version, ".")
+	if DBMSName == mysql && len(parts) > 1 {
+		return fmt.Sprintf("%s%s.%s", auroraEngine(DBMSName), parts[0], parts[1])
+	}
+
+	return auroraEngine(DBMSName) + parts[0]
+}
+
+// GenerateProject will generate the project directory on the users machine
+// with the Terraform project.
+func (a *AuroraProvider) GenerateProject(ddapikey string) error {
+	if err := a.fillTemplateData(ddapikey); err != nil {
+		return err
+	}
+
+	projectName := a.auroraTemplateData.Agent.ProjectName
+	if err := a.createProjectDirectory(projectName); err != nil {
+		return err
+	}
+
+	return a.renderProjectFiles(a.templateFS, projectName, a.projectFiles(), a.auroraTemplateData)
+}
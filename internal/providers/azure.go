@@ -0,0 +1,329 @@
+package providers
+
+import (
+	"fmt"
+	"io/fs"
+)
+
+const (
+	AZURE = "Azure"
+
+	// azureDatabase is the name of the Azure SQL database of the sandbox.
+	azureDatabase = "sandbox"
+)
+
+var (
+	// The indexes of where the Azure specific provider questions can be
+	// found, set when the questions are generated.
+	AzureLocationIndex uint8
+	AzureSKUIndex      uint8
+
+	// azureFlexibleServerSKUs are the SKUs the Flexible Servers can use.
+	azureFlexibleServerSKUs = []string{"B_Standard_B1ms", "B_Standard_B2s", "GP_Standard_D2ds_v4"}
+	// azureSQLDatabaseSKUs are the SKUs the Azure SQL database can use.
+	azureSQLDatabaseSKUs = []string{"Basic", "S0", "GP_S_Gen5_1"}
+	// azureSKUNotes holds the descriptions shown next to some of the SKUs.
+	azureSKUNotes = map[string]string{
+		"B_Standard_B1ms":     "cheapest, performance_schema is not supported for MySQL",
+		"GP_Standard_D2ds_v4": "recommended",
+		"Basic":               "cheapest",
+		"GP_S_Gen5_1":         "serverless",
+	}
+)
+
+// AzureProvider implements the Provider Interface and generates a Terraform
+// project that creates an Azure Database for PostgreSQL or MySQL Flexible
+// Server, or an Azure SQL Database, and a Container Instance running the
+// Datadog Agent.
+type AzureProvider struct {
+	eventEmitter
+	questionnaire
+
+	// supportedDBMS is a slice of names for all of the DBMS's that this provider
+	// supports.
+	supportedDBMS []string
+
+	// templatePath is the location in the templateFS where the templates for
+	// this provider are located.
+	templatePath string
+	// dbmsTemplatePath is the location in the templateFS of the DBMS
+	// configuration files, shared with the DockerProvider.
+	dbmsTemplatePath string
+	// templateData is the data required to fill the project template files for
+	// this provider.
+	templateData azureTemplateData
+	// templateFS is where the template files for this provider are located.
+	templateFS fs.FS
+}
+
+// azureTemplateData is used to contain the data for the AzureProvider
+// template files.
+type azureTemplateData struct {
+	Agent agentTemplateData
+	DB    dbTemplateData
+
+	// Name is the prefix of the name of every resource.
+	Name string
+	// Location is the Azure region the sandbox is created in.
+	Location string
+	// SKU is the SKU of the server, or of the Azure SQL database.
+	SKU string
+	// Check is the name of the agent integration check for the DBMS.
+	Check string
+	// Port is the port the DBMS listens on.
+	Port int
+	// Parameters are the DBM settings set on the server, the ApplyMethod isn't
+	// used by Azure.
+	Parameters []terraformParameter
+	// ParameterKeyWidth is the width of the longest quoted parameter name,
+	// used to align the Terraform map like terraform fmt does.
+	ParameterKeyWidth int
+	// ClientImage is the image of the init container that runs the bootstrap
+	// script.
+	ClientImage string
+	// AgentConf is the agent integration configuration for the DBMS, with the
+	// fully qualified domain name filled in by Terraform.
+	AgentConf string
+	// InitSQL is the SQL that creates the datadog user and its objects.
+	InitSQL string
+	// MonitorPassword is the password of the datadog login on Azure SQL.
+	MonitorPassword string
+	// Database is the name of the Azure SQL database.
+	Database string
+	// BootstrapFiles are the files mounted in the bootstrap init container.
+	BootstrapFiles []string
+	// BootstrapKeyWidth is the width of the longest quoted bootstrap file
+	// name, used to align the Terraform map like terraform fmt does.
+	BootstrapKeyWidth int
+}
+
+// GetAzureProvider will initiallize a new AzureProvider instance and return a
+// pointer to it.
+func GetAzureProvider() *AzureProvider {
+	ap := &AzureProvider{
+		supportedDBMS:    []string{postgres, mysql, sqlserver},
+		templatePath:     "embed/terraform/azure/",
+		dbmsTemplatePath: "embed/docker/",
+		templateFS:       templateFS,
+	}
+	ap.dbmsLookup = azureDBMS
+	ap.generateProviderQuestions()
+
+	return ap
+}
+
+// azureDBMS returns the DBMS with the versions supported by Azure.
+//
+// Supported Versions: https://learn.microsoft.com/en-us/azure/postgresql/flexible-server/concepts-supported-versions
+func azureDBMS(DBMSName string) DBMS {
+	switch DBMSName {
+	case mysql:
+		return newDBMS(mysql, []string{"8.0.21"}, map[string]string{})
+
+	case sqlserver:
+		return newDBMS(sqlserver, []string{"12.0"}, map[string]string{
+			"12.0": "Azure SQL Database",
+		})
+
+	default:
+		return PostgresDBMS()
+	}
+}
+
+// generateProviderQuestions generates all of the questions that are required
+// to fill the providers template data.
+func (a *AzureProvider) generateProviderQuestions() {
+	a.addCommonQuestions(a.supportedDBMS)
+
+	location := func() *Question {
+		question := &Question{
+			QType:         Input,
+			Prompt:        "What Azure location would you like to use?",
+			DefaultAnswer: "eastus",
+		}
+		a.QuestionAnswers = append(a.QuestionAnswers, question)
+
+		return question
+	}
+
+	sku := func() *Question {
+		skus := azureFlexibleServerSKUs
+		if a.answer(DBMSIndex) == sqlserver {
+			skus = azureSQLDatabaseSKUs
+		}
+
+		question := &Question{
+			QType:              Picker,
+			Prompt:             "What SKU would you like to use for the database?",
+			Options:            skus,
+			OptionDescriptions: describeOptions(skus, azureSKUNotes),
+			DefaultAnswer:      skus[1],
+		}
+		a.QuestionAnswers = append(a.QuestionAnswers, question)
+
+		return question
+	}
+
+	a.addQuestion(location, &AzureLocationIndex)
+	a.addQuestion(sku, &AzureSKUIndex)
+}
+
+// fillTemplateData will fill the AzureProvider.templateData with the answers,
+// the bootstrap SQL and the agent configuration.
+func (a *AzureProvider) fillTemplateData(ddapikey string) error {
+	dbmsName := a.answer(DBMSIndex)
+
+	data := azureTemplateData{
+		Agent: agentTemplateData{
+			Version:     a.answer(AgentVersionIndex),
+			DDAPIKey:    ddapikey,
+			ProjectName: a.answer(ProjectNameIndex),
+		},
+		DB: dbTemplateData{
+			DBMS:    dbmsName,
+			Version: a.answer(DBMSVersionIndex),
+		},
+		Name:            cloudResourceName(a.answer(ProjectNameIndex)),
+		Location:        a.answer(AzureLocationIndex),
+		SKU:             a.answer(AzureSKUIndex),
+		Check:           agentCheckName(dbmsName),
+		Port:            dbPort(dbmsName),
+		Parameters:      azureParameters(dbmsName),
+		ClientImage:     azureClientImage(dbmsName, a.answer(DBMSVersionIndex)),
+		MonitorPassword: sqlServerMonitorPassword,
+		Database:        azureDatabase,
+		BootstrapFiles:  []string{"bootstrap.sh", "bootstrap.sql"},
+	}
+
+	instance := agentInstance{
+		Host:          "${fqdn}",
+		Cloud:         "azure",
+		CloudSettings: [][2]string{{"deployment_type", "flexible_server"}, {"fully_qualified_domain_name", "${fqdn}"}},
+	}
+
+	if dbmsName == sqlserver {
+		data.BootstrapFiles = append(data.BootstrapFiles, "bootstrap-master.sql")
+		instance.Settings = [][2]string{{"username", "datadog"}, {"password", sqlServerMonitorPassword}, {"database", azureDatabase}}
+		instance.CloudSettings[0][1] = "sql_database"
+	} else {
+		initSQL, err := readInitSQL(a.templateFS, a.dbmsTemplatePath, dbmsName)
+		if err != nil {
+			return err
+		}
+		data.InitSQL = initSQL
+	}
+
+	agentConf, err := cloudAgentConf(a.templateFS, a.dbmsTemplatePath, dbmsName, []agentInstance{instance})
+	if err != nil {
+		return err
+	}
+	data.AgentConf = agentConf
+
+	parameterNames := []string{}
+	for _, parameter := range data.Parameters {
+		parameterNames = append(parameterNames, parameter.Name)
+	}
+	data.ParameterKeyWidth = quotedWidth(parameterNames)
+	data.BootstrapKeyWidth = quotedWidth(data.BootstrapFiles)
+
+	a.templateData = data
+	return nil
+}
+
+// projectFiles returns the files that make up the project, the database and
+// the bootstrap files depend on the DBMS.
+func (a *AzureProvider) projectFiles() []projectFile {
+	check := a.templateData.Check
+
+	files := []projectFile{
+		{template: a.templatePath + "README.md.tmpl", destination: "README.md"},
+		{template: a.templatePath + "versions.tf.tmpl", destination: "versions.tf"},
+		{template: a.templatePath + "variables.tf.tmpl", destination: "variables.tf"},
+		{template: a.templatePath + "main.tf.tmpl", destination: "main.tf"},
+		{template: a.templatePath + check + ".tf.tmpl", destination: "database.tf"},
+		{template: a.templatePath + "outputs.tf.tmpl", destination: "outputs.tf"},
+		{template: terraformTemplatePath + "terraform.tfvars.tmpl", destination: "terraform.tfvars"},
+		{template: a.templatePath + "conf.yaml.tftpl.tmpl", destination: "conf.yaml.tftpl"},
+		{template: a.templatePath + "bootstrap-" + check + ".sh.tmpl", destination: "bootstrap.sh"},
+	}
+
+	if check == agentCheckName(sqlserver) {
+		return append(files,
+			projectFile{template: a.templatePath + "bootstrap-sqlserver.sql.tmpl", destination: "bootstrap.sql"},
+			projectFile{template: a.templatePath + "bootstrap-sqlserver-master.sql.tmpl", destination: "bootstrap-master.sql"},
+		)
+	}
+
+	return append(files, projectFile{template: terraformTemplatePath + "bootstrap.sql.tmpl", destination: "bootstrap.sql"})
+}
+
+// GenerateProject will generate the project directory on the users machine
+// with the Terraform project.
+func (a *AzureProvider) GenerateProject(ddapikey string) error {
+	if err := a.fillTemplateData(ddapikey); err != nil {
+		return err
+	}
+
+	projectName := a.templateData.Agent.ProjectName
+	if err := a.createProjectDirectory(projectName); err != nil {
+		return err
+	}
+
+	return a.renderProjectFiles(a.templateFS, projectName, a.projectFiles(), a.templateData)
+}
+
+// azureParameters returns the server parameters Database Monitoring needs
+// for the DBMS, Azure SQL Database doesn't need any.
+func azureParameters(DBMSName string) []terraformParameter {
+	switch DBMSName {
+	case postgres:
+		return []terraformParameter{
+			{Name: "azure.extensions", Value: "PG_STAT_STATEMENTS"},
+			{Name: "pg_stat_statements.max", Value: "10000"},
+			{Name: "pg_stat_statements.track", Value: "ALL"},
+			{Name: "pg_stat_statements.track_utility", Value: "OFF"},
+			{Name: "track_activity_query_size", Value: "4096"},
+			{Name: "track_io_timing", Value: "ON"},
+		}
+
+	case mysql:
+		return []terraformParameter{
+			{Name: "performance_schema", Value: "ON"},
+			{Name: "max_digest_length", Value: "4096"},
+			{Name: "performance_schema_max_digest_length", Value: "4096"},
+			{Name: "performance_schema_max_sql_text_length", Value: "4096"},
+			// The agent and the bootstrap script connect without TLS.
+			{Name: "require_secure_transport", Value: "OFF"},
+		}
+
+	default:
+		return []terraformParameter{}
+	}
+}
+
+// azureClientImage returns the image used to run the bootstrap script, which
+// has the client of the DBMS.
+func azureClientImage(DBMSName string, version string) string {
+	switch DBMSName {
+	case mysql:
+		return "mysql:8.0"
+
+	case sqlserver:
+		return "mcr.microsoft.com/mssql-tools"
+
+	default:
+		return "postgres:" + version
+	}
+}
+
+// quotedWidth returns the length of the longest of the keys once quoted.
+func quotedWidth(keys []string) int {
+	width := 0
+	for _, key := range keys {
+		if quoted := len(fmt.Sprintf("%q", key)); quoted > width {
+			width = quoted
+		}
+	}
+
+	return width
+}
@@ -0,0 +1,281 @@
+package providers
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// embeddedCatalog is the catalog shipped with the tool.
+	//go:embed embed/catalog.yaml
+	embeddedCatalog []byte
+
+	// catalog is the catalog used by the providers, the embedded catalog with
+	// the local overrides applied on top.
+	catalog = mustParseCatalog(embeddedCatalog)
+)
+
+// A Catalog holds the versions of the Datadog Agent and of the DBMS's the
+// providers can deploy, and the agent versions they need.
+type Catalog struct {
+	Agent AgentCatalog           `yaml:"agent"`
+	DBMS  map[string]DBMSCatalog `yaml:"dbms"`
+}
+
+// AgentCatalog holds the versions of the Datadog Agent, the first one is the
+// default.
+type AgentCatalog struct {
+	Versions []CatalogVersion `yaml:"versions"`
+}
+
+// DBMSCatalog holds the versions of a DBMS, the first one is the default,
+// and the oldest agent that supports Database Monitoring for it.
+type DBMSCatalog struct {
+	MinAgentVersion string           `yaml:"min_agent_version"`
+	Versions        []CatalogVersion `yaml:"versions"`
+	// Features are the features of the agent integration the sandbox
+	// configuration relies on.
+	Features []CatalogFeature `yaml:"features"`
+}
+
+// A CatalogVersion is a version of the agent or of a DBMS.
+type CatalogVersion struct {
+	// Tag is the image tag of the version, it is the option shown to the user.
+	Tag string `yaml:"tag"`
+	// Note is shown next to the Tag, for example "recommended".
+	Note string `yaml:"note"`
+	// EOL is true when the version doesn't receive updates anymore.
+	EOL bool `yaml:"eol"`
+	// MinAgentVersion is the oldest agent that supports the version.
+	MinAgentVersion string `yaml:"min_agent_version"`
+}
+
+// A CatalogFeature is a feature of an agent integration.
+type CatalogFeature struct {
+	Name            string `yaml:"name"`
+	MinAgentVersion string `yaml:"min_agent_version"`
+}
+
+// GetCatalog returns the catalog used by the providers.
+func GetCatalog() Catalog {
+	return catalog
+}
+
+// AgentVersions returns the versions of the agent the providers can deploy,
+// the first one is the default, and their descriptions.
+func AgentVersions() ([]string, []string) {
+	return catalog.agentVersions()
+}
+
+// LoadCatalog reads the catalog file found at path and applies it on top of
+// the embedded catalog: its agent versions, and each of its DBMS's, replace
+// the embedded ones. A missing file is not an error unless required is true.
+func LoadCatalog(path string, required bool) error {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) && !required {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to read the catalog file %q, error: %q", path, err)
+	}
+
+	override, err := parseCatalog(content)
+	if err != nil {
+		return fmt.Errorf("Failed to parse the catalog file %q, error: %q", path, err)
+	}
+
+	catalog = mergeCatalogs(catalog, override)
+	return nil
+}
+
+// mustParseCatalog parses the embedded catalog, it panics since the tool
+// can't work without it.
+func mustParseCatalog(content []byte) Catalog {
+	c, err := parseCatalog(content)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to parse the embedded catalog, error: %q", err))
+	}
+
+	return c
+}
+
+// parseCatalog parses and validates a catalog, the sections it doesn't
+// define are left empty.
+func parseCatalog(content []byte) (Catalog, error) {
+	var c Catalog
+	if err := yaml.Unmarshal(content, &c); err != nil {
+		return c, err
+	}
+
+	if err := validateVersions("agent", c.Agent.Versions); err != nil {
+		return c, err
+	}
+
+	for name, dbms := range c.DBMS {
+		if len(dbms.Versions) == 0 {
+			return c, fmt.Errorf("%s has no versions", name)
+		}
+
+		if err := validateVersions(name, dbms.Versions); err != nil {
+			return c, err
+		}
+
+		if err := validateAgentVersion(name, dbms.MinAgentVersion); err != nil {
+			return c, err
+		}
+
+		for _, feature := range dbms.Features {
+			if err := validateAgentVersion(name+" "+feature.Name, feature.MinAgentVersion); err != nil {
+				return c, err
+			}
+		}
+	}
+
+	return c, nil
+}
+
+// validateVersions checks that every version has a tag and that their
+// minimum agent versions can be compared.
+func validateVersions(name string, versions []CatalogVersion) error {
+	for _, version := range versions {
+		if version.Tag == "" {
+			return fmt.Errorf("%s has a version without a tag", name)
+		}
+
+		if err := validateAgentVersion(name+" "+version.Tag, version.MinAgentVersion); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateAgentVersion checks that the minimum agent version is empty or a
+// version like 7.41.0.
+func validateAgentVersion(name string, version string) error {
+	if version == "" {
+		return nil
+	}
+
+	if _, ok := parseAgentVersion(version); !ok {
+		return fmt.Errorf("%s has an invalid min_agent_version: %q", name, version)
+	}
+
+	return nil
+}
+
+// mergeCatalogs returns the base catalog with the agent versions and the
+// DBMS's defined in the override replacing its own.
+func mergeCatalogs(base Catalog, override Catalog) Catalog {
+	merged := Catalog{
+		Agent: base.Agent,
+		DBMS:  map[string]DBMSCatalog{},
+	}
+
+	if len(override.Agent.Versions) > 0 {
+		merged.Agent = override.Agent
+	}
+
+	for name, dbms := range base.DBMS {
+		merged.DBMS[name] = dbms
+	}
+	for name, dbms := range override.DBMS {
+		merged.DBMS[name] = dbms
+	}
+
+	return merged
+}
+
+// agentVersions returns the tags of the agent versions and their
+// descriptions.
+func (c Catalog) agentVersions() ([]string, []string) {
+	return versionOptions(c.Agent.Versions)
+}
+
+// minAgentVersion returns the oldest agent that supports Database Monitoring
+// for the DBMS and every feature the sandbox relies on, empty when any agent
+// does.
+func (c Catalog) minAgentVersion(DBMSName string) string {
+	dbms := c.DBMS[DBMSName]
+
+	minimum := dbms.MinAgentVersion
+	for _, feature := range dbms.Features {
+		if feature.MinAgentVersion == "" {
+			continue
+		}
+
+		if minimum == "" || !agentSupports(minimum, feature.MinAgentVersion) {
+			minimum = feature.MinAgentVersion
+		}
+	}
+
+	return minimum
+}
+
+// versionOptions returns the tags of the versions and their descriptions,
+// which include the note and whether the version is EOL.
+func versionOptions(versions []CatalogVersion) ([]string, []string) {
+	tags := []string{}
+	descriptions := []string{}
+
+	for _, version := range versions {
+		eol := ""
+		if version.EOL {
+			eol = "EOL"
+		}
+
+		tags = append(tags, version.Tag)
+		descriptions = append(descriptions, joinNotes(eol, version.Note))
+	}
+
+	return tags, descriptions
+}
+
+// agentSupports reports whether the agent version is the minimum version or
+// newer. The latest agent, and versions that can't be compared like custom
+// builds, are assumed to support everything.
+func agentSupports(agentVersion string, minimum string) bool {
+	required, ok := parseAgentVersion(minimum)
+	if !ok {
+		return true
+	}
+
+	version, ok := parseAgentVersion(agentVersion)
+	if !ok {
+		return true
+	}
+
+	for ix := range required {
+		if version[ix] != required[ix] {
+			return version[ix] > required[ix]
+		}
+	}
+
+	return true
+}
+
+// parseAgentVersion parses the major, minor and patch numbers of an agent
+// version like 7.54.0, ok is false when it isn't one.
+func parseAgentVersion(version string) (parsed [3]int, ok bool) {
+	parts := strings.Split(version, ".")
+	if len(parts) != 3 {
+		return parsed, false
+	}
+
+	for ix, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return parsed, false
+		}
+		parsed[ix] = number
+	}
+
+	return parsed, true
+}
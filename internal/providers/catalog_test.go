@@ -0,0 +1,151 @@
+package providers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEmbeddedCatalogHasEveryDBMS(t *testing.T) {
+	c := mustParseCatalog(embeddedCatalog)
+
+	if len(c.Agent.Versions) == 0 {
+		t.Error("the embedded catalog has no agent versions")
+	}
+
+	for _, name := range []string{postgres, mysql, sqlserver} {
+		if len(c.DBMS[name].Versions) == 0 {
+			t.Errorf("the embedded catalog has no versions for %q", name)
+		}
+	}
+}
+
+func TestAgentSupports(t *testing.T) {
+	tests := []struct {
+		agent    string
+		minimum  string
+		expected bool
+	}{
+		{"7.54.0", "7.41.0", true},
+		{"7.41.0", "7.41.0", true},
+		{"7.40.9", "7.41.0", false},
+		{"7.9.0", "7.41.0", false},
+		{"6.60.0", "7.41.0", false},
+		{"latest", "7.41.0", true},
+		{"7.40.0", "", true},
+	}
+
+	for _, test := range tests {
+		if got := agentSupports(test.agent, test.minimum); got != test.expected {
+			t.Errorf("agentSupports(%q, %q) = %t, expected %t", test.agent, test.minimum, got, test.expected)
+		}
+	}
+}
+
+func TestMinAgentVersionIncludesFeatures(t *testing.T) {
+	c := Catalog{DBMS: map[string]DBMSCatalog{
+		sqlserver: {
+			MinAgentVersion: "7.41.0",
+			Features: []CatalogFeature{
+				{Name: "older", MinAgentVersion: "7.30.0"},
+				{Name: "newer", MinAgentVersion: "7.50.0"},
+			},
+		},
+	}}
+
+	if got := c.minAgentVersion(sqlserver); got != "7.50.0" {
+		t.Errorf("minAgentVersion = %q, expected 7.50.0", got)
+	}
+}
+
+func TestLoadCatalogOverridesDBMS(t *testing.T) {
+	original := catalog
+	t.Cleanup(func() { catalog = original })
+
+	path := filepath.Join(t.TempDir(), "catalog.yaml")
+	content := `
+dbms:
+  Postgres:
+    versions:
+    - tag: "17"
+      min_agent_version: 7.59.0
+    - tag: "16"
+      eol: true
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := LoadCatalog(path, true); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(catalog.Agent.Versions) != len(original.Agent.Versions) {
+		t.Error("the agent versions should be kept when the override doesn't define them")
+	}
+	if len(catalog.DBMS[mysql].Versions) != len(original.DBMS[mysql].Versions) {
+		t.Error("the DBMS's missing from the override should be kept")
+	}
+
+	question := PostgresDBMS().versionQuestion("7.58.0")
+	if len(question.DisabledOptions) != 1 || question.DisabledOptions[0] != "17" {
+		t.Errorf("expected 17 to be disabled, got %v", question.DisabledOptions)
+	}
+	if question.DefaultAnswer != "16" {
+		t.Errorf("expected the default to be 16, got %q", question.DefaultAnswer)
+	}
+	if question.OptionDescriptions[1] != "EOL" {
+		t.Errorf("expected 16 to be described as EOL, got %q", question.OptionDescriptions[1])
+	}
+}
+
+func TestLoadCatalogRejectsInvalidFiles(t *testing.T) {
+	original := catalog
+	t.Cleanup(func() { catalog = original })
+
+	dir := t.TempDir()
+	if err := LoadCatalog(filepath.Join(dir, "missing.yaml"), false); err != nil {
+		t.Errorf("an optional missing catalog should be ignored, got: %v", err)
+	}
+	if err := LoadCatalog(filepath.Join(dir, "missing.yaml"), true); err == nil {
+		t.Error("expected an error for a required missing catalog")
+	}
+
+	invalid := map[string]string{
+		"no-versions.yaml": "dbms:\n  Postgres:\n    min_agent_version: 7.36.1\n",
+		"no-tag.yaml":      "agent:\n  versions:\n  - note: recommended\n",
+		"bad-minimum.yaml": "dbms:\n  MySQL:\n    min_agent_version: seven\n    versions:\n    - tag: 8.0.37\n",
+	}
+	for name, content := range invalid {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := LoadCatalog(path, true); err == nil {
+			t.Errorf("expected %s to be rejected", name)
+		}
+	}
+}
+
+func TestEmbeddedCatalogDisablesUnsupportedDBMS(t *testing.T) {
+	questions := GetDockerProvider().GetProviderQuestions()
+
+	// The project name, the agent version and the skipped agent image
+	for ix, answer := range []string{"sandbox", "7.40.0", ""} {
+		if question := questions[ix](); question != nil {
+			question.Answer = answer
+		}
+	}
+
+	picker := questions[DBMSIndex]()
+	if len(picker.DisabledOptions) != 1 || picker.DisabledOptions[0] != sqlserver {
+		t.Fatalf("expected only SQL Server to be disabled for agent 7.40.0, got %v", picker.DisabledOptions)
+	}
+
+	for ix, option := range picker.Options {
+		if option == sqlserver && picker.OptionDescriptions[ix] != "requires agent 7.41.0+" {
+			t.Errorf("expected SQL Server to require agent 7.41.0+, got %q", picker.OptionDescriptions[ix])
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const (
+	// healthTimeout is how long to wait for the containers to be healthy.
+	healthTimeout = 3 * time.Minute
+	// healthInterval is how often the status of the containers is checked.
+	healthInterval = 2 * time.Second
+)
+
+// composeService is the part of the output of "compose ps --format json" that
+// is needed to know if a container is ready.
+type composeService struct {
+	Service string `json:"Service"`
+	State   string `json:"State"`
+	Health  string `json:"Health"`
+}
+
+// composeStarter starts a project using a compose command line tool, like
+// "docker compose".
+type composeStarter struct {
+	// command is the compose command and its arguments, for example
+	// []string{"docker", "compose"}.
+	command []string
+}
+
+// start pulls the images, starts the containers and waits for them to be
+// healthy. Each of these is emitted as a step, the commands are stopped when
+// the context is cancelled.
+func (c composeStarter) start(ctx context.Context, events *eventEmitter, directory string) error {
+	if err := events.runStep("Pull images", func() error {
+		_, err := c.run(ctx, directory, "pull")
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if err := events.runStep("Start containers", func() error {
+		_, err := c.run(ctx, directory, "up", "--detach")
+		return err
+	}); err != nil {
+		return err
+	}
+
+	return events.runStep("Wait for containers to be healthy", func() error {
+		return c.waitHealthy(ctx, directory)
+	})
+}
+
+// waitHealthy polls the state of the containers until all of them are running
+// and the ones with a health check are healthy.
+func (c composeStarter) waitHealthy(ctx context.Context, directory string) error {
+	deadline := time.Now().Add(healthTimeout)
+
+	for {
+		output, err := c.run(ctx, directory, "ps", "--all", "--format", "json")
+		if err != nil {
+			return err
+		}
+
+		services, err := parseComposeServices(output)
+		if err != nil {
+			return err
+		}
+
+		notReady := []string{}
+		for _, service := range services {
+			if service.State == "exited" || service.State == "dead" {
+				return fmt.Errorf("The %q container stopped, check its logs", service.Service)
+			}
+
+			if service.State != "running" || (service.Health != "" && service.Health != "healthy") {
+				notReady = append(notReady, service.Service)
+			}
+		}
+
+		if len(services) > 0 && len(notReady) == 0 {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("Timed out waiting for the containers to be healthy: %q", notReady)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("Interrupted while waiting for the containers to be healthy: %q", notReady)
+
+		case <-time.After(healthInterval):
+		}
+	}
+}
+
+// run runs the compose command with the arguments in the directory and
+// returns its output. The command is killed when the context is cancelled.
+func (c composeStarter) run(ctx context.Context, directory string, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+
+	command := exec.CommandContext(ctx, c.command[0], append(c.command[1:], args...)...)
+	command.Dir = directory
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+
+	if err := command.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("Interrupted while running %q", strings.Join(command.Args, " "))
+		}
+		return nil, fmt.Errorf("Failed to run %q, error: %q, output: %q", strings.Join(command.Args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// parseComposeServices parses the output of "compose ps --format json". Older
+// versions of compose print a JSON array while newer versions print one JSON
+// object per line, both are supported.
+func parseComposeServices(output []byte) ([]composeService, error) {
+	output = bytes.TrimSpace(output)
+	services := []composeService{}
+
+	if len(output) == 0 {
+		return services, nil
+	}
+
+	if output[0] == '[' {
+		if err := json.Unmarshal(output, &services); err != nil {
+			return nil, fmt.Errorf("Failed to parse the state of the containers, error: %q", err)
+		}
+		return services, nil
+	}
+
+	for _, line := range bytes.Split(output, []byte("\n")) {
+		var service composeService
+		if err := json.Unmarshal(line, &service); err != nil {
+			return nil, fmt.Errorf("Failed to parse the state of the containers, error: %q", err)
+		}
+		services = append(services, service)
+	}
+
+	return services, nil
+}
@@ -1,5 +1,12 @@
 package providers
 
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
 const (
 	postgres  = "Postgres"
 	mysql     = "MySQL"
@@ -16,14 +23,113 @@ type DBMS struct {
   // versions holds all of the versions that we support for the particular 
   // DBMS.
 	versions []string
+
+  // notes holds the descriptions shown next to some of the versions, like
+  // "EOL" or "recommended".
+	notes map[string]string
+
+	// minAgentVersion is the oldest agent that supports the DBMS, empty when
+	// any agent does.
+	minAgentVersion string
+
+	// versionMinAgent holds the oldest agent that supports some of the
+	// versions.
+	versionMinAgent map[string]string
 }
 
-// Helper function to create DBMS's 
-func newDBMS(name string, versions []string) DBMS {
+// Helper function to create DBMS's, the agent versions that support the DBMS
+// are found in the catalog.
+func newDBMS(name string, versions []string, notes map[string]string) DBMS {
 	return DBMS{
-		Name:     name,
-		versions: versions,
+		Name:            name,
+		versions:        versions,
+		notes:           notes,
+		minAgentVersion: catalog.minAgentVersion(name),
+		versionMinAgent: map[string]string{},
+	}
+}
+
+// catalogDBMS returns the DBMS with the versions found in the catalog.
+func catalogDBMS(name string) DBMS {
+	entry := catalog.DBMS[name]
+	tags, descriptions := versionOptions(entry.Versions)
+
+	notes := map[string]string{}
+	for ix, tag := range tags {
+		notes[tag] = descriptions[ix]
+	}
+
+	dbms := newDBMS(name, tags, notes)
+	for _, version := range entry.Versions {
+		dbms.versionMinAgent[version.Tag] = version.MinAgentVersion
+	}
+
+	return dbms
+}
+
+// Versions returns the versions of the DBMS, the first one is the default.
+func (d DBMS) Versions() []string {
+	return append([]string{}, d.versions...)
+}
+
+// VersionNote returns the description shown next to the version, like "EOL"
+// or "recommended".
+func (d DBMS) VersionNote(version string) string {
+	return d.notes[version]
+}
+
+// MinAgentVersion returns the oldest agent that supports the DBMS, empty when
+// any agent does.
+func (d DBMS) MinAgentVersion() string {
+	return d.minAgentVersion
+}
+
+// VersionMinAgentVersion returns the oldest agent that supports the version
+// of the DBMS, empty when any agent supporting the DBMS does.
+func (d DBMS) VersionMinAgentVersion(version string) string {
+	return d.versionMinAgent[version]
+}
+
+// versionDescriptions returns the description for each of the versions, in
+// the same order as the versions.
+func (d DBMS) versionDescriptions() []string {
+	return describeOptions(d.versions, d.notes)
+}
+
+// supportedBy reports whether the agent version supports the DBMS, when it
+// doesn't the reason is returned.
+func (d DBMS) supportedBy(agentVersion string) (bool, string) {
+	if agentSupports(agentVersion, d.minAgentVersion) {
+		return true, ""
 	}
+
+	return false, fmt.Sprintf("requires agent %s+", d.minAgentVersion)
+}
+
+// versionQuestion returns the picker of the versions of the DBMS, the
+// versions the agent version doesn't support are disabled.
+func (d DBMS) versionQuestion(agentVersion string) *Question {
+	question := &Question{
+		QType:              Picker,
+		Prompt:             "What version of the DBM would you like to use?",
+		Options:            d.versions,
+		OptionDescriptions: d.versionDescriptions(),
+	}
+
+	for ix, version := range d.versions {
+		minimum := d.versionMinAgent[version]
+		if agentSupports(agentVersion, minimum) {
+			if question.DefaultAnswer == "" {
+				question.DefaultAnswer = version
+			}
+			continue
+		}
+
+		question.DisabledOptions = append(question.DisabledOptions, version)
+		question.OptionDescriptions[ix] = joinNotes(question.OptionDescriptions[ix], fmt.Sprintf("requires agent %s+", minimum))
+	}
+
+	return question
 }
 
 // Returns the concrete DBMS implementation based on the provided input
@@ -44,29 +150,144 @@ func GetDBMS(DBMSName string) DBMS {
 
 }
 
-// Returns the Postgres DBMS concrete implementation
+// Returns the Postgres DBMS concrete implementation, with the versions found in
+// the catalog.
 // 
 // Available Versions: https://hub.docker.com/_/postgres/tags
 //
 // Supported Versions: https://docs.datadoghq.com/database_monitoring/setup_postgres/selfhosted/?tab=postgres15
 func PostgresDBMS() DBMS {
-	return newDBMS(postgres, []string{"16", "15", "14", "13", "12"})
+	return catalogDBMS(postgres)
 }
 
-// Returns the MySQL DBMS concrete implementation
+// Returns the MySQL DBMS concrete implementation, with the versions found in
+// the catalog.
 // 
 // Available Versions: https://hub.docker.com/_/mysql/tags
 //
 // Supported Versions: https://docs.datadoghq.com/database_monitoring/setup_mysql/selfhosted/?tab=mysql57
 func MySQLDBMS() DBMS {
-	return newDBMS(mysql, []string{"8.0.37", "8.0.36", "8.0.35", "8.0.34", "8.0.33"})
+	return catalogDBMS(mysql)
 }
 
-// Returns the MySQL DBMS concrete implementation
+// Returns the MySQL DBMS concrete implementation, with the versions found in
+// the catalog.
 // 
 // Available Versions: https://hub.docker.com/_/microsoft-mssql-server
 // 
 // Supported Versions: https://docs.datadoghq.com/database_monitoring/setup_sql_server/selfhosted/?tab=sqlserver2014
 func SQLServerDBMS() DBMS {
-	return newDBMS(sqlserver, []string{"2022-latest", "2019-latest", "2017-latest"})
+	return catalogDBMS(sqlserver)
+}
+
+// agentCheckName returns the name of the agent integration check of the DBMS.
+func agentCheckName(DBMSName string) string {
+	switch DBMSName {
+	case mysql:
+		return "mysql"
+
+	case sqlserver:
+		return "sqlserver"
+
+	default:
+		return "postgres"
+	}
+}
+
+// agentConfDirectory returns the name of the directory, inside the agent
+// conf.d directory, where the integration configuration of the DBMS lives.
+func agentConfDirectory(DBMSName string) string {
+	return agentCheckName(DBMSName) + ".d"
+}
+
+// dbServiceName returns the name of the service, or host, the DBMS is
+// reachable at in the container based sandboxes.
+func dbServiceName(DBMSName string) string {
+	switch DBMSName {
+	case mysql:
+		return "mysql"
+
+	case sqlserver:
+		return "ssql"
+
+	default:
+		return "postgres"
+	}
+}
+
+// dbPort returns the port the DBMS listens on.
+func dbPort(DBMSName string) int {
+	switch DBMSName {
+	case mysql:
+		return 3306
+
+	case sqlserver:
+		return 1433
+
+	default:
+		return 5432
+	}
+}
+
+// DBMSPorts returns the port each DBMS listens on, by name, the Dev Container
+// forwards it to the host.
+func DBMSPorts() map[string]int {
+	return map[string]int{
+		postgres:  dbPort(postgres),
+		mysql:     dbPort(mysql),
+		sqlserver: dbPort(sqlserver),
+	}
+}
+
+// A dbmsFile is one of the configuration files of a DBMS found in the embed
+// tree and where it is mounted in the DBMS container.
+type dbmsFile struct {
+	// Source is the location of the file, relative to the directory of the
+	// DBMS in the embed tree.
+	Source string
+	// MountPath is where the file is mounted in the DBMS container.
+	MountPath string
+}
+
+// dbmsFiles returns the configuration files of the DBMS, the init SQL and the
+// server configuration, in the same layout as the compose file mounts them.
+func dbmsFiles(DBMSName string) []dbmsFile {
+	switch DBMSName {
+	case postgres:
+		return []dbmsFile{
+			{Source: "postgres/postgresql.conf", MountPath: "/etc/postgresql/postgresql.conf"},
+			{Source: "postgres/init.sql", MountPath: "/docker-entrypoint-initdb.d/init.sql"},
+		}
+
+	case mysql:
+		return []dbmsFile{
+			{Source: "mysql/conf.d/datadog.cnf", MountPath: "/etc/mysql/conf.d/datadog.cnf"},
+			{Source: "mysql/init-sql/datadog-conf.sql", MountPath: "/docker-entrypoint-initdb.d/datadog-conf.sql"},
+		}
+
+	default:
+		return []dbmsFile{}
+	}
+}
+
+// replaceAgentHost replaces the host of the DBMS service in the agent
+// integration configuration, for the sandboxes where the DBMS isn't
+// reachable using the compose service name.
+func replaceAgentHost(conf string, DBMSName string, host string) string {
+	service := regexp.MustCompile(`(host:\s*'?)` + regexp.QuoteMeta(dbServiceName(DBMSName)) + `\b`)
+
+	// The host is escaped since $ has a meaning in the replacement.
+	return service.ReplaceAllString(conf, "${1}"+strings.ReplaceAll(host, "$", "$$"))
+}
+
+// dbmsInitSQL returns the init SQL of the DBMS, which creates the datadog user
+// and the objects the agent needs. ok is false when the DBMS doesn't have one.
+func dbmsInitSQL(DBMSName string) (file dbmsFile, ok bool) {
+	for _, file := range dbmsFiles(DBMSName) {
+		if path.Ext(file.Source) == ".sql" {
+			return file, true
+		}
+	}
+
+	return dbmsFile{}, false
 }
@@ -1,49 +1,79 @@
 package providers
 
 import (
-	"bytes"
+	"context"
 	"embed"
 	"fmt"
-	"os"
+	"io/fs"
 	"strings"
-	"text/template"
 
+	"github.com/aldrickdev/dbm-sandbox/internal/packs"
+	"github.com/aldrickdev/dbm-sandbox/internal/scenarios"
 	"github.com/aldrickdev/dbm-sandbox/internal/utils/helpers"
+	"github.com/aldrickdev/dbm-sandbox/internal/validate"
 )
 
 var (
-	// templateFS is where the template files used to create to the project are
-	// embeded.
-	//go:embed embed/docker/*
-	templateFS embed.FS
-
-	// The indexes of where the provider questions can be found, these are set
-	// when questions are generated.
-	ProjectNameIndex  uint8
-	AgentVersionIndex uint8
-	DBMSIndex         uint8
-	DBMSVersionIndex  uint8
+	// embeddedTemplates is where the template files used to create to the
+	// project are embeded.
+	//go:embed embed/docker/* embed/podman/* embed/kubernetes/* embed/terraform/* embed/vagrant/* embed/devcontainer/*
+	embeddedTemplates embed.FS
+
+	// templateFS is where the providers find their template files, the
+	// embedded templates with the templates directory of the user on top.
+	templateFS fs.FS = embeddedTemplates
+
+	// The indexes of where the Docker specific provider questions can be
+	// found, set when the questions are generated.
+	DockerScenarioIndex     uint8
+	DockerDevContainerIndex uint8
 )
 
+// noScenario is the option of the scenario picker used to not break the
+// sandbox.
+const noScenario = "none"
+
 // DockerProvider implements the Provider Interface and holds all the required
 // information needed to create a Docker Project.
 type DockerProvider struct {
-	// QuestionAnswers is a slice of *Question for this provider.
-	QuestionAnswers []*Question
+	eventEmitter
+	questionnaire
+
+	// name is the name of the provider, as registered.
+	name string
+
 	// supportedDBMS is a slice of names for all of the DBMS's that this provider
 	// supports.
 	supportedDBMS []string
-	// questionFuncs is a slice of functions that returns a *Question.
-	questionFuncs []func() *Question
 
 	// templatePath is the location in the templateFS where the templates for
 	// this provider are located.
 	templatePath string
+	// composeTemplate is the location in the templateFS of the template used
+	// to create the compose file.
+	composeTemplate string
+	// devContainerPath is the location in the templateFS of the templates
+	// used to create the Dev Container configuration, empty when it isn't
+	// offered.
+	devContainerPath string
+	// containerSocket is the socket of the container engine that is mounted
+	// in the agent container.
+	containerSocket string
+	// qualifyImages prefixes the images without a registry with the Docker
+	// Hub registry, for the container engines that don't assume it.
+	qualifyImages bool
 	// templateData is the data required to fill the project template files for
 	// this provider.
 	templateData dockerTemplateData
 	// templateFS is where the template files for this provider are located.
-	templateFS embed.FS
+	templateFS fs.FS
+	// starter is used to start the project once it was generated.
+	starter composeStarter
+	// installedPacks are the template packs offered by the pack picker.
+	installedPacks []packs.Pack
+	// packQuestionIndexes holds the indexes of the questions of each pack, by
+	// pack name.
+	packQuestionIndexes map[string][]uint8
 }
 
 // dockerTemplateData is used to contain the data for the DockerProvider
@@ -52,6 +82,7 @@ type DockerProvider struct {
 type dockerTemplateData struct {
 	Agent agentTemplateData
 	DB    dbTemplateData
+	Pack  packTemplateData
 }
 
 // agentTemplateData is used to contain the agent data for the
@@ -60,10 +91,16 @@ type agentTemplateData struct {
 	// Version is used to contain the version of the agent that the user would
 	// like to deploy.
 	Version string
+	// Image is used to contain the reference of the agent image, the official
+	// image of the Version or the custom image of the user.
+	Image string
 	// DDAPIKey is used to contain the Datadog API Key of the user.
 	DDAPIKey string
 	// ProjectName is used to contain the name of the directory for the project.
 	ProjectName string
+	// ContainerSocket is used to contain the socket of the container engine
+	// that is mounted in the agent container.
+	ContainerSocket string
 }
 
 // dbTemplateData is used to contain all of the data for the
@@ -74,21 +111,47 @@ type dbTemplateData struct {
 	DBMS string
 	// Version is used to contain the Version of the DBMS to use for the project.
 	Version string
+	// Image is used to contain the reference of the DBMS image, the official
+	// image of the Version or the custom image of the user.
+	Image string
+}
+
+// devContainerTemplateData is used to contain the data for the Dev Container
+// configuration, which adds a workspace service with the DBMS clients to the
+// compose project.
+type devContainerTemplateData struct {
+	dockerTemplateData
+	// Service is the name of the compose service of the DBMS.
+	Service string
+	// Port is the port the DBMS listens on.
+	Port int
 }
 
 // GetDockerProvider will initiallize a new DockerProvider instance and return
 // a pointer to it.
 func GetDockerProvider() *DockerProvider {
+	return newDockerProvider("embed/devcontainer/")
+}
+
+// newDockerProvider returns a new DockerProvider, the user is only offered to
+// generate a Dev Container configuration when the devContainerPath is set.
+func newDockerProvider(devContainerPath string) *DockerProvider {
 	dp := new(DockerProvider)
+	dp.name = DOCKER
 	supportedDBMS := dp.getSupportedDMBS()
 	supportedDBMSNames := []string{}
 	for _, dbms := range supportedDBMS {
 		supportedDBMSNames = append(supportedDBMSNames, dbms.Name)
 	}
 	dp.supportedDBMS = supportedDBMSNames
+	dp.devContainerPath = devContainerPath
+	dp.customImages = true
 	dp.generateProviderQuestions()
 	dp.templatePath = "embed/docker/"
+	dp.composeTemplate = dp.templatePath + "docker-compose.tmpl"
+	dp.containerSocket = "/var/run/docker.sock"
 	dp.templateFS = templateFS
+	dp.starter = composeStarter{command: []string{"docker", "compose"}}
 
 	return dp
 }
@@ -106,69 +169,50 @@ func (d *DockerProvider) getSupportedDMBS() []DBMS {
 // generateProviderQuestions generates all of the questions that are required
 // to fill the providers template data.
 func (d *DockerProvider) generateProviderQuestions() {
-	projectName := func() *Question {
+	d.addCommonQuestions(d.supportedDBMS)
+	d.addPackQuestions()
+
+	// The scenarios only describe their symptom so that they don't give the
+	// answer away
+	scenario := func() *Question {
 		question := &Question{
-			QType:         Input,
-			Prompt:        "What is your project name?",
-			DefaultAnswer: "dbm-sandbox",
+			QType:              Picker,
+			Prompt:             "Would you like to break the sandbox to practice troubleshooting?",
+			Options:            []string{noScenario},
+			OptionDescriptions: []string{""},
+			DefaultAnswer:      noScenario,
 		}
 
-		d.QuestionAnswers = append(d.QuestionAnswers, question)
-
-		return question
-	}
-
-	agentVersion := func() *Question {
-		question := &Question{
-			QType:   Picker,
-			Prompt:  "What version of the agent would you like to use?",
-			Options: AgentVersions,
+		for _, s := range scenarios.ForDBMS(d.answer(DBMSIndex)) {
+			question.Options = append(question.Options, s.Name)
+			question.OptionDescriptions = append(question.OptionDescriptions, s.Symptom)
 		}
 		d.QuestionAnswers = append(d.QuestionAnswers, question)
 
-		return question
-	}
-	dbmsPicker := func() *Question {
-		question := &Question{
-			QType:   Picker,
-			Prompt:  "What DBMS would you like to use?",
-			Options: d.supportedDBMS,
+		if len(question.Options) == 1 {
+			return nil
 		}
-		d.QuestionAnswers = append(d.QuestionAnswers, question)
 
 		return question
 	}
-	dbmsVersionInput := func() *Question {
-		selectedDBMS := d.QuestionAnswers[DBMSIndex].Answer
-		dbmsInfo := GetDBMS(selectedDBMS)
 
+	devContainer := func() *Question {
 		question := &Question{
-			QType:   Picker,
-			Prompt:  "What version of the DBM would you like to use?",
-			Options: dbmsInfo.versions,
+			QType:              Picker,
+			Prompt:             "Would you like to generate a Dev Container configuration?",
+			Options:            []string{no, yes},
+			OptionDescriptions: []string{"", "Creates .devcontainer to open the sandbox in an IDE with the DBMS client installed"},
+			DefaultAnswer:      no,
 		}
 		d.QuestionAnswers = append(d.QuestionAnswers, question)
 
 		return question
 	}
 
-	d.addQuestion(projectName, &ProjectNameIndex)
-	d.addQuestion(agentVersion, &AgentVersionIndex)
-	d.addQuestion(dbmsPicker, &DBMSIndex)
-	d.addQuestion(dbmsVersionInput, &DBMSVersionIndex)
-}
-
-// addQuestion will set the proper index for the location of the question so
-// that they can be accessed correctly in fillTemplateData.
-func (d *DockerProvider) addQuestion(q func() *Question, indexVariable *uint8) {
-	*indexVariable = uint8(len(d.questionFuncs))
-	d.questionFuncs = append(d.questionFuncs, q)
-}
-
-// GetProviderQuestions provides a slice of functions that return a *Question
-// for the provider.
-func (d *DockerProvider) GetProviderQuestions() []func() *Question {
-	return d.questionFuncs
+	d.addQuestion(scenario, &DockerScenarioIndex)
+	if d.devContainerPath != "" {
+		d.addQuestion(devContainer, &DockerDevContainerIndex)
+	}
 }
 
 // fillTemplateData will fill the DockerProvider.templateData with the answers
@@ -176,60 +220,126 @@ func (d *DockerProvider) GetProviderQuestions() []func() *Question {
 func (d *DockerProvider) fillTemplateData(ddapikey string) {
 	d.templateData = dockerTemplateData{
 		Agent: agentTemplateData{
-			Version:     d.QuestionAnswers[AgentVersionIndex].Answer,
-			DDAPIKey:    ddapikey,
-			ProjectName: d.QuestionAnswers[ProjectNameIndex].Answer,
+			Version:         d.agentVersion(),
+			Image:           d.image(d.agentImage()),
+			DDAPIKey:        ddapikey,
+			ProjectName:     d.QuestionAnswers[ProjectNameIndex].Answer,
+			ContainerSocket: d.containerSocket,
 		},
 		DB: dbTemplateData{
 			DBMS:    d.QuestionAnswers[DBMSIndex].Answer,
-			Version: d.QuestionAnswers[DBMSVersionIndex].Answer,
+			Version: d.dbmsVersion(),
+			Image:   d.image(d.dbmsImage()),
 		},
+		Pack: d.packTemplateData(),
 	}
 
 }
 
+// image returns the image reference as the container engine expects it.
+func (d *DockerProvider) image(reference string) string {
+	if d.qualifyImages {
+		return qualifyImage(reference)
+	}
+
+	return reference
+}
+
 // GenerateProject will generate the project directory on the users machine
-// using the templates and template data.
+// using the templates and template data, then write its manifest.
 func (d *DockerProvider) GenerateProject(ddapikey string) error {
+	if err := d.generateProject(ddapikey); err != nil {
+		return err
+	}
+
+	return d.writeManifest()
+}
+
+// generateProject renders the project files. The templates of the template
+// pack the user picked replace the provider templates, and its files are added
+// to the project.
+func (d *DockerProvider) generateProject(ddapikey string) error {
 	d.fillTemplateData(ddapikey)
 
-	if err := helpers.CheckDirectory(d.templateData.Agent.ProjectName); err != nil {
+	pack, usePack := d.selectedPack()
+	if usePack {
+		d.templateFS = overlayFS{
+			upper: mountFS{fsys: pack.TemplatesFS(), prefix: templatesRoot},
+			lower: d.templateFS,
+		}
+	}
+
+	projectName := d.templateData.Agent.ProjectName
+	if err := d.createProjectDirectory(projectName); err != nil {
 		return err
 	}
 
-	if err := helpers.CreateDirectory(d.templateData.Agent.ProjectName); err != nil {
+	dbms := d.templatePath + strings.ToLower(d.templateData.DB.DBMS)
+	if err := d.runStep(fmt.Sprintf("Copy the %s configuration files", d.templateData.DB.DBMS), func() error {
+		return helpers.CopyDirectoryFS(d.templateFS, dbms, projectName)
+	}); err != nil {
 		return err
 	}
 
-	var content bytes.Buffer
+	if err := d.renderProjectFiles(d.templateFS, projectName, []projectFile{
+		{template: d.composeTemplate, destination: "docker-compose.yaml"},
+	}, d.templateData); err != nil {
+		return err
+	}
+
+	if usePack {
+		files, err := packFiles(pack)
+		if err != nil {
+			return fmt.Errorf("Failed to list the files of the pack %q, error: %q", pack.Name, err)
+		}
 
-	providerDirectory := d.templatePath
-	composeTemplatePath := d.templatePath + "docker-compose.tmpl"
+		if err := d.renderProjectFiles(pack.FilesFS(), projectName, files, d.templateData); err != nil {
+			return err
+		}
+	}
 
-	dbms := providerDirectory + strings.ToLower(d.templateData.DB.DBMS)
-	if err := helpers.CopyDirectoryFS(d.templateFS, dbms, d.templateData.Agent.ProjectName); err != nil {
+	// The project is validated before a scenario breaks it on purpose
+	if err := d.runStep("Validate the project", func() error {
+		return validate.Error(validate.Project(projectName))
+	}); err != nil {
 		return err
 	}
 
-	temp := template.Must(template.New("docker-compose.tmpl").ParseFS(d.templateFS, composeTemplatePath))
+	if s, ok := scenarios.Get(d.answer(DockerScenarioIndex)); ok {
+		if err := d.runStep("Break the sandbox", func() error {
+			return s.Apply(projectName)
+		}); err != nil {
+			return err
+		}
+	}
 
-	if err := temp.Execute(&content, d.templateData); err != nil {
-		return fmt.Errorf("Failed to execute the template: %q, error: %q", "docker-compose.yaml", err)
+	if d.devContainerPath == "" || d.answer(DockerDevContainerIndex) != yes {
+		return nil
 	}
 
-	fullProviderLocation := d.templateData.Agent.ProjectName + "/"
+	return d.generateDevContainer()
+}
 
-	// Creates template
-	newFile := fullProviderLocation + "docker-compose.yaml"
-	f, err := os.Create(newFile)
-	if err != nil {
-		return fmt.Errorf("Failed to create file: %q, error: %q", "docker-compose.yaml", err)
+// generateDevContainer renders the Dev Container configuration, which
+// references the compose file of the project and adds a workspace service
+// to it.
+func (d *DockerProvider) generateDevContainer() error {
+	dbmsName := d.templateData.DB.DBMS
+	data := devContainerTemplateData{
+		dockerTemplateData: d.templateData,
+		Service:            dbServiceName(dbmsName),
+		Port:               dbPort(dbmsName),
 	}
-	defer f.Close()
 
-	if _, err := f.WriteString(content.String()); err != nil {
-		return fmt.Errorf("Failed to write to file: %q, error: %q", "docker-compose.yaml", err)
-	}
+	return d.renderProjectFiles(d.templateFS, d.templateData.Agent.ProjectName, []projectFile{
+		{template: d.devContainerPath + "devcontainer.json.tmpl", destination: ".devcontainer/devcontainer.json"},
+		{template: d.devContainerPath + "docker-compose.yaml.tmpl", destination: ".devcontainer/docker-compose.yaml"},
+		{template: d.devContainerPath + "Dockerfile.tmpl", destination: ".devcontainer/Dockerfile"},
+	}, data)
+}
 
-	return nil
+// StartProject pulls the images and starts the containers of the generated
+// project using docker compose, then waits for them to be healthy.
+func (d *DockerProvider) StartProject(ctx context.Context) error {
+	return d.starter.start(ctx, &d.eventEmitter, d.templateData.Agent.ProjectName)
 }
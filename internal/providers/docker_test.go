@@ -0,0 +1,115 @@
+package providers
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestDockerGolden(t *testing.T) {
+	runGoldenCases(t, []goldenCase{
+		{
+			name:     "docker-postgres-16",
+			provider: func() Provider { return GetDockerProvider() },
+			answers:  []string{"latest", postgres, "16", noScenario, no},
+		},
+		{
+			name:     "docker-postgres-12-devcontainer",
+			provider: func() Provider { return GetDockerProvider() },
+			answers:  []string{"7.58.0", postgres, "12", noScenario, yes},
+		},
+		{
+			name:     "docker-postgres-scenario",
+			provider: func() Provider { return GetDockerProvider() },
+			answers:  []string{"latest", postgres, "15", "pg-no-explain-plans", no},
+		},
+		{
+			name:     "docker-mysql-8.0.37",
+			provider: func() Provider { return GetDockerProvider() },
+			answers:  []string{"latest", mysql, "8.0.37", noScenario, no},
+		},
+		{
+			name:     "docker-mysql-8.0.33-devcontainer",
+			provider: func() Provider { return GetDockerProvider() },
+			answers:  []string{"7.59.0", mysql, "8.0.33", noScenario, yes},
+		},
+		{
+			name:     "docker-mysql-custom-images",
+			provider: func() Provider { return GetDockerProvider() },
+			answers:  []string{customImage, "registry.example.com/agent:7.61.0-rc.1", mysql, customImage, "registry.example.com/mysql:8.4", noScenario, no},
+		},
+		{
+			name:     "docker-sqlserver-2022",
+			provider: func() Provider { return GetDockerProvider() },
+			answers:  []string{"latest", sqlserver, "2022-latest", no},
+		},
+		{
+			name:     "docker-sqlserver-2017-devcontainer",
+			provider: func() Provider { return GetDockerProvider() },
+			answers:  []string{"7.60.0", sqlserver, "2017-latest", yes},
+		},
+	}, checkComposeFile)
+}
+
+// checkComposeFile reports the compose files that aren't valid YAML or that
+// have whitespace left over by the template actions: tabs, lines that only
+// contain whitespace and empty lines at the end.
+func checkComposeFile(t *testing.T, file string, content []byte) {
+	t.Helper()
+
+	if !strings.HasSuffix(file, "docker-compose.yaml") {
+		return
+	}
+
+	var compose struct {
+		Services map[string]interface{} `yaml:"services"`
+	}
+	if err := yaml.Unmarshal(content, &compose); err != nil {
+		t.Errorf("%s: invalid YAML: %s", file, err)
+	}
+	if len(compose.Services) == 0 {
+		t.Errorf("%s: has no services", file)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	for ix, line := range lines {
+		if strings.Contains(line, "\t") {
+			t.Errorf("%s:%d: contains a tab", file, ix+1)
+		}
+
+		if line != "" && strings.TrimSpace(line) == "" {
+			t.Errorf("%s:%d: only contains whitespace", file, ix+1)
+		}
+
+		if line == "" && ix == len(lines)-1 {
+			t.Errorf("%s:%d: ends with an empty line", file, ix+1)
+		}
+	}
+}
+
+func TestReadManifest(t *testing.T) {
+	generateGoldenProject(t, goldenCase{
+		name:     "manifest",
+		provider: goldenPodmanProvider,
+		answers:  []string{"latest", postgres, "16", noScenario, yes},
+	})
+
+	manifest, ok, err := ReadManifest(goldenProjectName)
+	if err != nil || !ok {
+		t.Fatalf("no manifest was read, error: %v", err)
+	}
+
+	if manifest.Provider != PODMAN || manifest.Compose != "podman compose" {
+		t.Errorf("the manifest is for %q using %q, expected %q using %q", manifest.Provider, manifest.Compose, PODMAN, "podman compose")
+	}
+
+	expected := []string{"conf.d/postgres.d/conf.yaml", "docker-compose.yaml", "kube.yaml", "postgres/init.sql", "postgres/postgresql.conf"}
+	if strings.Join(manifest.Files, ",") != strings.Join(expected, ",") {
+		t.Errorf("the manifest lists %q, expected %q", manifest.Files, expected)
+	}
+
+	if _, ok, err := ReadManifest(t.TempDir()); ok || err != nil {
+		t.Errorf("a manifest was read from an empty directory, error: %v", err)
+	}
+}
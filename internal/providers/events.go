@@ -0,0 +1,85 @@
+package providers
+
+import "fmt"
+
+type StepStatus int
+
+const (
+	StepStarted StepStatus = iota
+	StepSucceeded
+	StepFailed
+)
+
+// stepStatusNames are the names of the StepStatus values, used when the events
+// are encoded.
+var stepStatusNames = map[StepStatus]string{
+	StepStarted:   "started",
+	StepSucceeded: "succeeded",
+	StepFailed:    "failed",
+}
+
+func (s StepStatus) String() string {
+	return stepStatusNames[s]
+}
+
+// MarshalText encodes the StepStatus using its name, so that the JSON output
+// is readable.
+func (s StepStatus) MarshalText() ([]byte, error) {
+	name, ok := stepStatusNames[s]
+	if !ok {
+		return nil, fmt.Errorf("Unknown step status: %d", s)
+	}
+
+	return []byte(name), nil
+}
+
+// An Event is emitted by a Provider each time one of the steps it goes through
+// while generating or starting a project changes status. The events are what
+// drive the progress views, so every output mode shows the same steps.
+type Event struct {
+	// Step is the human readable name of the step, for example "Pull images".
+	Step string `json:"step"`
+
+	// Status is the new status of the step.
+	Status StepStatus `json:"status"`
+
+	// Error holds the reason the step failed, it is only set for the StepFailed
+	// status.
+	Error string `json:"error,omitempty"`
+}
+
+// An EventHandler is called with every Event a Provider emits.
+type EventHandler func(Event)
+
+// eventEmitter can be embedded in a Provider to implement the SetEventHandler
+// method of the Provider interface.
+type eventEmitter struct {
+	handler EventHandler
+}
+
+// SetEventHandler sets the function that will be called with each of the
+// events emitted by the provider.
+func (e *eventEmitter) SetEventHandler(handler EventHandler) {
+	e.handler = handler
+}
+
+// emit sends the event to the handler, if one was set.
+func (e *eventEmitter) emit(event Event) {
+	if e.handler != nil {
+		e.handler(event)
+	}
+}
+
+// runStep runs the function as a named step, emitting an event when the step
+// starts and another one when it succeeds or fails.
+func (e *eventEmitter) runStep(step string, fn func() error) error {
+	e.emit(Event{Step: step, Status: StepStarted})
+
+	if err := fn(); err != nil {
+		e.emit(Event{Step: step, Status: StepFailed, Error: err.Error()})
+		return err
+	}
+
+	e.emit(Event{Step: step, Status: StepSucceeded})
+	return nil
+}
@@ -0,0 +1,342 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aldrickdev/dbm-sandbox/internal/utils/helpers"
+)
+
+const (
+	// ExternalProviderPrefix is the prefix of the executables, found on the
+	// PATH, that are used as external providers.
+	ExternalProviderPrefix = "dbm-sandbox-provider-"
+
+	// externalTimeout is how long an external provider has to answer a request.
+	externalTimeout = 30 * time.Second
+
+	// The commands an external provider is called with, as its first argument.
+	externalDescribe = "describe"
+	externalQuestion = "question"
+	externalGenerate = "generate"
+)
+
+// externalDescribeTimeout is how long an external provider has to describe
+// itself, the providers are described every time the provider picker is
+// shown so a slow one can't hold it up for long.
+var externalDescribeTimeout = 3 * time.Second
+
+// External providers are executables that speak a JSON protocol over
+// stdin/stdout which mirrors the Provider interface. Each request runs the
+// executable once with the command as its first argument:
+//
+//	describe: no input, returns an externalDescription.
+//	question: receives an externalRequest with the index of the question and
+//	          the answers so far, returns a Question.
+//	generate: receives an externalRequest with every answer and the API Key,
+//	          returns an externalProject with the files to write.
+//
+// Any response can instead be {"error": "..."} to report a failure.
+
+// externalDescription is the response to the describe command.
+type externalDescription struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Questions is the number of questions the provider will ask.
+	Questions int    `json:"questions"`
+	Error     string `json:"error,omitempty"`
+}
+
+// externalRequest is the input sent to the question and generate commands.
+type externalRequest struct {
+	Index   int      `json:"index"`
+	Answers []string `json:"answers"`
+	APIKey  string   `json:"api_key,omitempty"`
+}
+
+// externalQuestionResponse is the response to the question command.
+type externalQuestionResponse struct {
+	Question
+	Error string `json:"error,omitempty"`
+}
+
+// externalFile is a file the external provider wants written in the project
+// directory.
+type externalFile struct {
+	// Path is relative to the project directory, it can't leave it.
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+// externalProject is the response to the generate command.
+type externalProject struct {
+	// Directory is the name of the project directory.
+	Directory string         `json:"directory"`
+	Files     []externalFile `json:"files"`
+	Error     string         `json:"error,omitempty"`
+}
+
+// ExternalProvider implements the Provider Interface by forwarding every call
+// to an external executable.
+type ExternalProvider struct {
+	eventEmitter
+
+	// QuestionAnswers is a slice of *Question for this provider.
+	QuestionAnswers []*Question
+	// questionFuncs is a slice of functions that returns a *Question.
+	questionFuncs []func() *Question
+
+	// path is the location of the executable.
+	path string
+}
+
+// RegisterExternalProviders looks for external providers on the PATH and
+// registers them, in the order they were found. They are described
+// concurrently. Providers that fail to describe themselves in time, or whose
+// name is already registered, are skipped and reported in the returned
+// errors.
+func RegisterExternalProviders() []error {
+	paths := findExternalProviders()
+	descriptions := make([]externalDescription, len(paths))
+	describeErrs := make([]error, len(paths))
+
+	var wg sync.WaitGroup
+	for ix, path := range paths {
+		wg.Add(1)
+		go func(ix int, path string) {
+			defer wg.Done()
+			describeErrs[ix] = callExternal(path, externalDescribe, externalDescribeTimeout, nil, &descriptions[ix])
+		}(ix, path)
+	}
+	wg.Wait()
+
+	errs := []error{}
+	for ix, path := range paths {
+		description := descriptions[ix]
+		if describeErrs[ix] != nil {
+			errs = append(errs, describeErrs[ix])
+			continue
+		}
+		if description.Error != "" {
+			errs = append(errs, fmt.Errorf("External provider %q failed to describe itself: %s", path, description.Error))
+			continue
+		}
+
+		path := path
+		r := Registration{
+			Name:        description.Name,
+			Description: description.Description,
+			Factory: func() Provider {
+				return newExternalProvider(path, description.Questions)
+			},
+		}
+		if err := validateRegistration(r); err != nil {
+			errs = append(errs, fmt.Errorf("External provider %q was skipped: %s", path, err))
+			continue
+		}
+
+		Register(r)
+	}
+
+	return errs
+}
+
+// findExternalProviders returns the location of every executable on the PATH
+// that starts with ExternalProviderPrefix. Like the shell, the first
+// executable found with a given name wins.
+func findExternalProviders() []string {
+	found := map[string]bool{}
+	paths := []string{}
+
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			name := entry.Name()
+			if !strings.HasPrefix(name, ExternalProviderPrefix) || found[name] {
+				continue
+			}
+
+			info, err := entry.Info()
+			if err != nil || info.IsDir() || !isExecutable(info) {
+				continue
+			}
+
+			found[name] = true
+			paths = append(paths, filepath.Join(dir, name))
+		}
+	}
+
+	return paths
+}
+
+// isExecutable reports whether the file can be executed. Windows doesn't have
+// an executable bit so the extension is used instead.
+func isExecutable(info fs.FileInfo) bool {
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(filepath.Ext(info.Name()), ".exe")
+	}
+
+	return info.Mode()&0111 != 0
+}
+
+// newExternalProvider returns a provider backed by the executable at path,
+// which asks the given number of questions.
+func newExternalProvider(path string, questions int) *ExternalProvider {
+	ep := &ExternalProvider{path: path}
+
+	for ix := 0; ix < questions; ix++ {
+		ix := ix
+		ep.questionFuncs = append(ep.questionFuncs, func() *Question {
+			return ep.question(ix)
+		})
+	}
+
+	return ep
+}
+
+// question asks the external provider for the question at the index. When the
+// provider fails the error is shown as the prompt of an Input question with
+// no default, which lets the user quit.
+func (e *ExternalProvider) question(index int) *Question {
+	response := externalQuestionResponse{}
+	request := externalRequest{Index: index, Answers: e.answers()}
+
+	err := callExternal(e.path, externalQuestion, externalTimeout, request, &response)
+	if err == nil && response.Error != "" {
+		err = fmt.Errorf("%s", response.Error)
+	}
+
+	question := &response.Question
+	if err != nil {
+		question = &Question{
+			QType:  Input,
+			Prompt: fmt.Sprintf("The provider failed to send its question (%s), press enter to quit", err),
+		}
+	}
+	question.Answer = ""
+
+	e.QuestionAnswers = append(e.QuestionAnswers, question)
+	return question
+}
+
+// answers returns the answers given so far.
+func (e *ExternalProvider) answers() []string {
+	answers := []string{}
+	for _, question := range e.QuestionAnswers {
+		answers = append(answers, question.Answer)
+	}
+
+	return answers
+}
+
+// GetProviderQuestions provides a slice of functions that return a *Question
+// for the provider.
+func (e *ExternalProvider) GetProviderQuestions() []func() *Question {
+	return e.questionFuncs
+}
+
+// GenerateProject asks the external provider for the files of the project and
+// writes them in the project directory.
+func (e *ExternalProvider) GenerateProject(ddapikey string) error {
+	project := externalProject{}
+	if err := e.runStep("Render the project", func() error {
+		request := externalRequest{Answers: e.answers(), APIKey: ddapikey}
+		if err := callExternal(e.path, externalGenerate, externalTimeout, request, &project); err != nil {
+			return err
+		}
+		if project.Error != "" {
+			return fmt.Errorf("The provider failed to generate the project: %s", project.Error)
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := e.runStep(fmt.Sprintf("Create directory %q", project.Directory), func() error {
+		if !filepath.IsLocal(project.Directory) {
+			return fmt.Errorf("The project directory %q must be a relative path", project.Directory)
+		}
+
+		if err := helpers.CheckDirectory(project.Directory); err != nil {
+			return err
+		}
+
+		return helpers.CreateDirectory(project.Directory)
+	}); err != nil {
+		return err
+	}
+
+	for _, file := range project.Files {
+		file := file
+		if err := e.runStep(fmt.Sprintf("Write %s", file.Path), func() error {
+			return writeProjectFile(project.Directory, file)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeProjectFile writes the file inside the project directory, creating the
+// parent directories it needs.
+func writeProjectFile(directory string, file externalFile) error {
+	if !filepath.IsLocal(file.Path) {
+		return fmt.Errorf("The file %q must be a relative path inside the project", file.Path)
+	}
+
+	path := filepath.Join(directory, file.Path)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("Failed to create the directory for %q, error: %q", file.Path, err)
+	}
+
+	return helpers.WriteFile(path, []byte(file.Content))
+}
+
+// callExternal runs the executable with the command, sends the request as
+// JSON on its stdin and decodes its stdout into the response. The executable
+// is killed when it doesn't answer before the timeout.
+func callExternal(path string, command string, timeout time.Duration, request any, response any) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var stdin, stdout, stderr bytes.Buffer
+	if request != nil {
+		if err := json.NewEncoder(&stdin).Encode(request); err != nil {
+			return fmt.Errorf("Failed to encode the %q request, error: %q", command, err)
+		}
+	}
+
+	cmd := exec.CommandContext(ctx, path, command)
+	cmd.Stdin = &stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// The children of the executable can keep its output open once it is
+	// killed
+	cmd.WaitDelay = time.Second
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("External provider %q failed to run %q, error: %q, output: %q", filepath.Base(path), command, err, strings.TrimSpace(stderr.String()))
+	}
+
+	if err := json.Unmarshal(stdout.Bytes(), response); err != nil {
+		return fmt.Errorf("External provider %q sent an invalid %q response, error: %q", filepath.Base(path), command, err)
+	}
+
+	return nil
+}
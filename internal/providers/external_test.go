@@ -0,0 +1,150 @@
+package providers
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// testExternalProvider is a shell script that implements the external
+// provider protocol with a single question.
+const testExternalProvider = `#!/bin/sh
+case "$1" in
+describe)
+	echo '{"name": "Lab", "description": "Uses the lab environment", "questions": 1}'
+	;;
+question)
+	echo '{"type": "picker", "prompt": "Which lab?", "options": ["east", "west"], "default": "west"}'
+	;;
+generate)
+	read -r input
+	case "$input" in
+	*'"answers":["east"]'*) lab=east ;;
+	*) lab=west ;;
+	esac
+	echo '{"directory": "lab-project", "files": [{"path": "conf/lab.txt", "content": "'$lab'"}]}'
+	;;
+esac
+`
+
+func TestExternalProvider(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test external provider is a shell script")
+	}
+
+	saved := registry
+	defer func() { registry = saved }()
+
+	binDir := t.TempDir()
+	script := filepath.Join(binDir, ExternalProviderPrefix+"lab")
+	if err := os.WriteFile(script, []byte(testExternalProvider), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir)
+
+	if errs := RegisterExternalProviders(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	provider := GetProvider("Lab")
+	if provider == nil {
+		t.Fatal("the external provider was not registered")
+	}
+
+	questions := provider.GetProviderQuestions()
+	if len(questions) != 1 {
+		t.Fatalf("got %d questions, expected 1", len(questions))
+	}
+
+	question := questions[0]()
+	if question.QType != Picker || question.Prompt != "Which lab?" || question.DefaultAnswer != "west" {
+		t.Fatalf("unexpected question: %+v", question)
+	}
+	question.Answer = "east"
+
+	workDir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(workDir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	if err := provider.GenerateProject("api-key"); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(workDir, "lab-project", "conf", "lab.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "east" {
+		t.Errorf("got %q, expected the answer to be used", content)
+	}
+}
+
+func TestExternalProviderNameCollision(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test external provider is a shell script")
+	}
+
+	saved := registry
+	defer func() { registry = saved }()
+
+	binDir := t.TempDir()
+	script := filepath.Join(binDir, ExternalProviderPrefix+"docker")
+	content := "#!/bin/sh\necho '{\"name\": \"" + DOCKER + "\", \"description\": \"Another Docker\", \"questions\": 0}'\n"
+	if err := os.WriteFile(script, []byte(content), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir)
+
+	if errs := RegisterExternalProviders(); len(errs) != 1 {
+		t.Fatalf("got %d errors, expected the duplicate provider to be reported", len(errs))
+	}
+}
+
+func TestSlowExternalProviderIsSkipped(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test external provider is a shell script")
+	}
+
+	saved, savedTimeout := registry, externalDescribeTimeout
+	defer func() { registry, externalDescribeTimeout = saved, savedTimeout }()
+	externalDescribeTimeout = 500 * time.Millisecond
+
+	sleep, err := exec.LookPath("sleep")
+	if err != nil {
+		t.Skip("sleep is not installed")
+	}
+
+	binDir := t.TempDir()
+	scripts := map[string]string{
+		"slow": "#!/bin/sh\n" + sleep + " 10\n",
+		"lab":  testExternalProvider,
+	}
+	for name, content := range scripts {
+		if err := os.WriteFile(filepath.Join(binDir, ExternalProviderPrefix+name), []byte(content), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", binDir)
+
+	start := time.Now()
+	errs := RegisterExternalProviders()
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("describing the providers took %s, the slow one should time out", elapsed)
+	}
+
+	if len(errs) != 1 {
+		t.Errorf("got %v, expected the slow provider to be reported", errs)
+	}
+	if GetProvider("Lab") == nil {
+		t.Error("the provider that answered in time was not registered")
+	}
+}
@@ -0,0 +1,328 @@
+package providers
+
+import (
+	"io/fs"
+	"os"
+	"strings"
+)
+
+const (
+	GCP = "GCP"
+
+	// sqlServerAdmin is the built in administrator of the Cloud SQL for SQL
+	// Server instances, its password is set when the instance is created.
+	sqlServerAdmin = "sqlserver"
+)
+
+var (
+	// The indexes of where the GCP specific provider questions can be found,
+	// set when the questions are generated.
+	GCPProjectIndex uint8
+	GCPRegionIndex  uint8
+	GCPTierIndex    uint8
+
+	// cloudSQLTiers are the machine tiers the Postgres and MySQL instances can
+	// use.
+	cloudSQLTiers = []string{"db-g1-small", "db-custom-1-3840", "db-custom-2-7680"}
+	// cloudSQLServerTiers are the machine tiers the SQL Server instances can
+	// use, they don't support the shared core tiers.
+	cloudSQLServerTiers = []string{"db-custom-2-7680", "db-custom-4-15360"}
+	// cloudSQLTierNotes holds the descriptions shown next to some of the
+	// tiers.
+	cloudSQLTierNotes = map[string]string{
+		"db-g1-small":       "cheapest, shared core",
+		"db-custom-1-3840":  "recommended",
+		"db-custom-2-7680":  "recommended for SQL Server",
+		"db-custom-4-15360": "",
+	}
+)
+
+// GCPProvider implements the Provider Interface and generates a Terraform
+// project that creates a Cloud SQL instance, configured for Database
+// Monitoring, and a Compute Engine VM running the Datadog Agent.
+type GCPProvider struct {
+	eventEmitter
+	questionnaire
+
+	// supportedDBMS is a slice of names for all of the DBMS's that this provider
+	// supports.
+	supportedDBMS []string
+
+	// templatePath is the location in the templateFS where the templates for
+	// this provider are located.
+	templatePath string
+	// dbmsTemplatePath is the location in the templateFS of the DBMS
+	// configuration files, shared with the DockerProvider.
+	dbmsTemplatePath string
+	// templateData is the data required to fill the project template files for
+	// this provider.
+	templateData gcpTemplateData
+	// templateFS is where the template files for this provider are located.
+	templateFS fs.FS
+}
+
+// gcpTemplateData is used to contain the data for the GCPProvider template
+// files.
+type gcpTemplateData struct {
+	Agent agentTemplateData
+	DB    dbTemplateData
+
+	// Name is the prefix of the name of every resource.
+	Name string
+	// Project is the ID of the GCP project the sandbox is created in.
+	Project string
+	// Region is the GCP region the sandbox is created in.
+	Region string
+	// Tier is the machine tier of the Cloud SQL instance.
+	Tier string
+	// DatabaseVersion is the Cloud SQL name of the DBMS and its version.
+	DatabaseVersion string
+	// Flags are the DBM settings set as database flags, the ApplyMethod isn't
+	// used by Cloud SQL.
+	Flags []terraformParameter
+	// Check is the name of the agent integration check for the DBMS.
+	Check string
+	// Port is the port the DBMS listens on.
+	Port int
+	// DBUsername is the administrator used to run the bootstrap SQL.
+	DBUsername string
+	// AgentMinorVersion is the minor version of the agent to install, empty to
+	// install the latest version.
+	AgentMinorVersion string
+	// AgentConf is the agent integration configuration for the DBMS.
+	AgentConf string
+	// InitSQL is the SQL that creates the datadog user and its objects.
+	InitSQL string
+	// MonitorPassword is the password of the datadog login on SQL Server.
+	MonitorPassword string
+}
+
+// GetGCPProvider will initiallize a new GCPProvider instance and return a
+// pointer to it.
+func GetGCPProvider() *GCPProvider {
+	gp := &GCPProvider{
+		supportedDBMS:    []string{postgres, mysql, sqlserver},
+		templatePath:     "embed/terraform/gcp/",
+		dbmsTemplatePath: "embed/docker/",
+		templateFS:       templateFS,
+	}
+	gp.dbmsLookup = cloudSQLDBMS
+	gp.generateProviderQuestions()
+
+	return gp
+}
+
+// cloudSQLDBMS returns the DBMS with the versions supported by Cloud SQL,
+// SQL Server versions include the edition.
+//
+// Supported Versions: https://cloud.google.com/sql/docs/db-versions
+func cloudSQLDBMS(DBMSName string) DBMS {
+	switch DBMSName {
+	case mysql:
+		return newDBMS(mysql, []string{"8.0", "5.7"}, map[string]string{
+			"8.0": "recommended",
+		})
+
+	case sqlserver:
+		return newDBMS(sqlserver, []string{"2022_STANDARD", "2022_EXPRESS", "2019_STANDARD"}, map[string]string{
+			"2022_EXPRESS": "cheapest, no SQL Server Agent",
+		})
+
+	default:
+		return newDBMS(postgres, []string{"16", "15", "14", "13"}, map[string]string{
+			"16": "recommended",
+		})
+	}
+}
+
+// generateProviderQuestions generates all of the questions that are required
+// to fill the providers template data.
+func (g *GCPProvider) generateProviderQuestions() {
+	g.addCommonQuestions(g.supportedDBMS)
+
+	project := func() *Question {
+		question := &Question{
+			QType:         Input,
+			Prompt:        "What GCP project ID would you like to use?",
+			DefaultAnswer: os.Getenv("GOOGLE_CLOUD_PROJECT"),
+		}
+		g.QuestionAnswers = append(g.QuestionAnswers, question)
+
+		return question
+	}
+
+	region := func() *Question {
+		question := &Question{
+			QType:         Input,
+			Prompt:        "What GCP region would you like to use?",
+			DefaultAnswer: "us-central1",
+		}
+		g.QuestionAnswers = append(g.QuestionAnswers, question)
+
+		return question
+	}
+
+	tier := func() *Question {
+		tiers := cloudSQLTiers
+		if g.answer(DBMSIndex) == sqlserver {
+			tiers = cloudSQLServerTiers
+		}
+
+		question := &Question{
+			QType:              Picker,
+			Prompt:             "What machine tier would you like to use for the database?",
+			Options:            tiers,
+			OptionDescriptions: describeOptions(tiers, cloudSQLTierNotes),
+			DefaultAnswer:      tiers[0],
+		}
+		g.QuestionAnswers = append(g.QuestionAnswers, question)
+
+		return question
+	}
+
+	g.addQuestion(project, &GCPProjectIndex)
+	g.addQuestion(region, &GCPRegionIndex)
+	g.addQuestion(tier, &GCPTierIndex)
+}
+
+// fillTemplateData will fill the GCPProvider.templateData with the answers,
+// the setup SQL and the agent configuration.
+func (g *GCPProvider) fillTemplateData(ddapikey string) error {
+	dbmsName := g.answer(DBMSIndex)
+	version := g.answer(DBMSVersionIndex)
+
+	data := gcpTemplateData{
+		Agent: agentTemplateData{
+			Version:     g.answer(AgentVersionIndex),
+			DDAPIKey:    ddapikey,
+			ProjectName: g.answer(ProjectNameIndex),
+		},
+		DB: dbTemplateData{
+			DBMS:    dbmsName,
+			Version: version,
+		},
+		Name:              cloudResourceName(g.answer(ProjectNameIndex)),
+		Project:           g.answer(GCPProjectIndex),
+		Region:            g.answer(GCPRegionIndex),
+		Tier:              g.answer(GCPTierIndex),
+		DatabaseVersion:   cloudSQLDatabaseVersion(dbmsName, version),
+		Flags:             cloudSQLFlags(dbmsName),
+		Check:             agentCheckName(dbmsName),
+		Port:              dbPort(dbmsName),
+		DBUsername:        "sandbox_admin",
+		AgentMinorVersion: agentMinorVersion(g.answer(AgentVersionIndex)),
+		MonitorPassword:   sqlServerMonitorPassword,
+	}
+
+	instance := agentInstance{
+		Host:          "${db_host}",
+		Cloud:         "gcp",
+		CloudSettings: [][2]string{{"project_id", "${project_id}"}, {"instance_id", "${instance_id}"}},
+	}
+
+	if dbmsName == sqlserver {
+		data.DBUsername = sqlServerAdmin
+		// The agent runs on the VM, which uses the Microsoft ODBC driver instead
+		// of the FreeTDS driver of the agent container image.
+		instance.Settings = [][2]string{
+			{"username", "datadog"},
+			{"password", sqlServerMonitorPassword},
+			{"driver", "ODBC Driver 18 for SQL Server"},
+			{"connection_string", "TrustServerCertificate=yes;"},
+		}
+	} else {
+		initSQL, err := readInitSQL(g.templateFS, g.dbmsTemplatePath, dbmsName)
+		if err != nil {
+			return err
+		}
+		data.InitSQL = initSQL
+	}
+
+	agentConf, err := cloudAgentConf(g.templateFS, g.dbmsTemplatePath, dbmsName, []agentInstance{instance})
+	if err != nil {
+		return err
+	}
+	data.AgentConf = agentConf
+
+	g.templateData = data
+	return nil
+}
+
+// projectFiles returns the files that make up the project, SQL Server uses
+// its own setup SQL since its init SQL is run by the DBMS container.
+func (g *GCPProvider) projectFiles() []projectFile {
+	files := []projectFile{
+		{template: g.templatePath + "README.md.tmpl", destination: "README.md"},
+		{template: g.templatePath + "versions.tf.tmpl", destination: "versions.tf"},
+		{template: g.templatePath + "variables.tf.tmpl", destination: "variables.tf"},
+		{template: g.templatePath + "main.tf.tmpl", destination: "main.tf"},
+		{template: g.templatePath + "outputs.tf.tmpl", destination: "outputs.tf"},
+		{template: terraformTemplatePath + "terraform.tfvars.tmpl", destination: "terraform.tfvars"},
+		{template: g.templatePath + "startup-script.sh.tftpl.tmpl", destination: "startup-script.sh.tftpl"},
+	}
+
+	if g.templateData.Check == agentCheckName(sqlserver) {
+		return append(files, projectFile{template: g.templatePath + "bootstrap-sqlserver.sql.tmpl", destination: "bootstrap.sql"})
+	}
+
+	return append(files, projectFile{template: terraformTemplatePath + "bootstrap.sql.tmpl", destination: "bootstrap.sql"})
+}
+
+// GenerateProject will generate the project directory on the users machine
+// with the Terraform project.
+func (g *GCPProvider) GenerateProject(ddapikey string) error {
+	if err := g.fillTemplateData(ddapikey); err != nil {
+		return err
+	}
+
+	projectName := g.templateData.Agent.ProjectName
+	if err := g.createProjectDirectory(projectName); err != nil {
+		return err
+	}
+
+	return g.renderProjectFiles(g.templateFS, projectName, g.projectFiles(), g.templateData)
+}
+
+// cloudSQLDatabaseVersion returns the Cloud SQL database version of the DBMS,
+// for example POSTGRES_16, MYSQL_8_0 or SQLSERVER_2022_STANDARD.
+func cloudSQLDatabaseVersion(DBMSName string, version string) string {
+	version = strings.ReplaceAll(version, ".", "_")
+
+	switch DBMSName {
+	case mysql:
+		return "MYSQL_" + version
+
+	case sqlserver:
+		return "SQLSERVER_" + version
+
+	default:
+		return "POSTGRES_" + version
+	}
+}
+
+// cloudSQLFlags returns the database flags Database Monitoring needs for the
+// DBMS, as documented in the Datadog setup guides for Cloud SQL. SQL Server
+// doesn't need any.
+func cloudSQLFlags(DBMSName string) []terraformParameter {
+	switch DBMSName {
+	case postgres:
+		return []terraformParameter{
+			{Name: "pg_stat_statements.max", Value: "10000"},
+			{Name: "pg_stat_statements.track", Value: "all"},
+			{Name: "pg_stat_statements.track_utility", Value: "off"},
+			{Name: "track_activity_query_size", Value: "4096"},
+			{Name: "track_io_timing", Value: "on"},
+		}
+
+	case mysql:
+		return []terraformParameter{
+			{Name: "performance_schema", Value: "on"},
+			{Name: "max_digest_length", Value: "4096"},
+			{Name: "performance_schema_max_digest_length", Value: "4096"},
+			{Name: "performance_schema_max_sql_text_length", Value: "4096"},
+		}
+
+	default:
+		return []terraformParameter{}
+	}
+}
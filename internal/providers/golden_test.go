@@ -0,0 +1,246 @@
+package providers
+
+import (
+	"bytes"
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/aldrickdev/dbm-sandbox/internal/apikey"
+)
+
+// update rewrites the golden files with the generated projects, run
+// go test ./internal/providers -update and review the diff.
+var update = flag.Bool("update", false, "update the golden files")
+
+// goldenDirectory replaces the temporary directory the projects are generated
+// in, in the files that contain absolute paths.
+const goldenDirectory = "/golden"
+
+// goldenProjectName is the answer to the project name question of every
+// golden case, which is also the directory the project is generated in.
+const goldenProjectName = "sandbox"
+
+// A goldenCase is a project generated by a provider from a set of answers and
+// compared to the files found in testdata/golden/<name>.
+type goldenCase struct {
+	name     string
+	provider func() Provider
+	// answers are the answers to the questions the provider asks, in order,
+	// after the project name. The skipped questions aren't answered.
+	answers []string
+}
+
+// runGoldenCases generates the project of each case and compares it to its
+// golden files. check is called with every generated file.
+func runGoldenCases(t *testing.T, cases []goldenCase, check func(t *testing.T, path string, content []byte)) {
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// The golden files are found before the working directory changes.
+			golden, err := filepath.Abs(filepath.Join("testdata", "golden", tc.name))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			project := generateGoldenProject(t, tc)
+
+			if *update {
+				if err := os.RemoveAll(golden); err != nil {
+					t.Fatal(err)
+				}
+				writeTree(t, golden, project)
+			}
+
+			expected := readTree(t, golden)
+			compareTrees(t, expected, project)
+
+			if check != nil {
+				for path, content := range project {
+					check(t, path, content)
+				}
+			}
+		})
+	}
+}
+
+// generateGoldenProject answers the questions of the provider and generates
+// its project in a temporary directory, returning the generated files.
+func generateGoldenProject(t *testing.T, tc goldenCase) map[string][]byte {
+	t.Helper()
+
+	provider := tc.provider()
+	answers := append([]string{goldenProjectName}, tc.answers...)
+
+	asked := 0
+	for _, questionFunc := range provider.GetProviderQuestions() {
+		question := questionFunc()
+		if question == nil {
+			continue
+		}
+
+		if asked >= len(answers) {
+			t.Fatalf("the provider asks more than the %d questions answered", len(answers))
+		}
+		question.Answer = answers[asked]
+		asked++
+
+		if question.QType == Picker && !enabledOption(question, question.Answer) {
+			t.Fatalf("%q isn't an option of %q, expected one of %q", question.Answer, question.Prompt, question.Options)
+		}
+
+		if question.Validate != nil {
+			if err := question.Validate(question.Answer); err != nil {
+				t.Fatalf("invalid answer to %q: %s", question.Prompt, err)
+			}
+		}
+	}
+
+	if asked != len(answers) {
+		t.Fatalf("the provider asks %d questions but %d answers were given", asked, len(answers))
+	}
+
+	directory := t.TempDir()
+	chdir(t, directory)
+
+	if err := provider.GenerateProject(apikey.Placeholder); err != nil {
+		t.Fatalf("failed to generate the project: %s", err)
+	}
+
+	files := readTree(t, filepath.Join(directory, goldenProjectName))
+
+	// The temporary directory can be a symbolic link, the absolute paths use
+	// either of them
+	directories := []string{directory}
+	if resolved, err := filepath.EvalSymlinks(directory); err == nil && resolved != directory {
+		directories = append(directories, resolved)
+	}
+	for path, content := range files {
+		for _, d := range directories {
+			content = bytes.ReplaceAll(content, []byte(filepath.ToSlash(d)), []byte(goldenDirectory))
+		}
+		files[path] = content
+	}
+
+	return files
+}
+
+// enabledOption reports whether the answer is one of the options of the
+// picker that isn't disabled.
+func enabledOption(question *Question, answer string) bool {
+	for _, disabled := range question.DisabledOptions {
+		if disabled == answer {
+			return false
+		}
+	}
+
+	for _, option := range question.Options {
+		if option == answer {
+			return true
+		}
+	}
+
+	return false
+}
+
+// chdir changes the working directory for the duration of the test, the
+// providers generate their project in the working directory.
+func chdir(t *testing.T, directory string) {
+	t.Helper()
+
+	previous, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chdir(directory); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := os.Chdir(previous); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+// readTree returns the content of every file under the root, keyed by their
+// slash separated path relative to the root.
+func readTree(t *testing.T, root string) map[string][]byte {
+	t.Helper()
+
+	files := map[string][]byte{}
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		relative, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		files[filepath.ToSlash(relative)] = content
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to read %q: %s", root, err)
+	}
+
+	return files
+}
+
+// writeTree writes the files under the root.
+func writeTree(t *testing.T, root string, files map[string][]byte) {
+	t.Helper()
+
+	for path, content := range files {
+		destination := filepath.Join(root, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(destination), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(destination, content, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// compareTrees reports the files that are missing, unexpected or different.
+func compareTrees(t *testing.T, expected map[string][]byte, actual map[string][]byte) {
+	t.Helper()
+
+	paths := []string{}
+	for path := range expected {
+		paths = append(paths, path)
+	}
+	for path := range actual {
+		if _, ok := expected[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		want, inExpected := expected[path]
+		got, inActual := actual[path]
+
+		switch {
+		case !inActual:
+			t.Errorf("%s: missing from the generated project", path)
+
+		case !inExpected:
+			t.Errorf("%s: not in the golden files, run the tests with -update", path)
+
+		case !bytes.Equal(want, got):
+			t.Errorf("%s: differs from the golden file, run the tests with -update and review the diff\n--- got ---\n%s", path, got)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package providers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// customImage is the option of the agent and DBMS version pickers that
+	// lets the user enter an image reference instead.
+	customImage = "custom"
+
+	// agentRepository is the repository of the official agent image.
+	agentRepository = "gcr.io/datadoghq/agent"
+
+	// maxImageNameLength is the longest repository name, domain included, an
+	// image reference can have.
+	maxImageNameLength = 255
+)
+
+var (
+	// imageReference matches an image reference like registry:5000/repo:tag,
+	// following the grammar of the distribution project. The name, tag and
+	// digest are captured.
+	imageReference = regexp.MustCompile(`^(` +
+		// The optional registry with its port, followed by the path components
+		`(?:(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9])(?:\.(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9]))*(?::[0-9]+)?/)?` +
+		`[a-z0-9]+(?:(?:[._]|__|[-]+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|[-]+)[a-z0-9]+)*)*` +
+		`)` +
+		`(?::([\w][\w.-]{0,127}))?` +
+		`(?:@([A-Za-z][A-Za-z0-9]*(?:[-_+.][A-Za-z][A-Za-z0-9]*)*:[0-9a-fA-F]{32,}))?$`)
+
+	// dbmsRepositories are the repositories of the official DBMS images.
+	dbmsRepositories = map[string]string{
+		postgres:  "postgres",
+		mysql:     "mysql",
+		sqlserver: "mcr.microsoft.com/mssql/server",
+	}
+)
+
+// validateImageReference checks that the reference is a valid image
+// reference, like registry.example.com/datadog/agent:7.60.0-rc.1.
+func validateImageReference(reference string) error {
+	matches := imageReference.FindStringSubmatch(reference)
+	if matches == nil {
+		return fmt.Errorf("Invalid image reference %q, expected something like registry/repository:tag", reference)
+	}
+
+	if len(matches[1]) > maxImageNameLength {
+		return fmt.Errorf("Invalid image reference %q, the repository name is longer than %d characters", reference, maxImageNameLength)
+	}
+
+	return nil
+}
+
+// imageTag returns the tag of the image reference, latest when it doesn't
+// have one.
+func imageTag(reference string) string {
+	matches := imageReference.FindStringSubmatch(reference)
+	if matches == nil || matches[2] == "" {
+		return "latest"
+	}
+
+	return matches[2]
+}
+
+// qualifyImage prefixes the image reference with the Docker Hub registry when
+// it doesn't include one, for the container engines like Podman that don't
+// assume it.
+func qualifyImage(reference string) string {
+	first, rest, found := strings.Cut(reference, "/")
+	if !found {
+		return "docker.io/library/" + reference
+	}
+
+	if strings.ContainsAny(first, ".:") || first == "localhost" {
+		return reference
+	}
+
+	return "docker.io/" + first + "/" + rest
+}
+
+// agentImage returns the official agent image of the version.
+func agentImage(version string) string {
+	return agentRepository + ":" + version
+}
+
+// dbmsImage returns the official image of the version of the DBMS.
+func dbmsImage(DBMSName string, version string) string {
+	return dbmsRepositories[DBMSName] + ":" + version
+}
@@ -0,0 +1,110 @@
+package providers
+
+import "testing"
+
+func TestValidateImageReference(t *testing.T) {
+	valid := []string{
+		"postgres",
+		"postgres:16",
+		"datadog/agent:7.60.0-jmx",
+		"gcr.io/datadoghq/agent:7.61.0-rc.1-fips",
+		"registry.example.com:5000/mirror/mssql/server:2022-latest",
+		"localhost:5000/agent",
+		"mysql@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+	}
+	for _, reference := range valid {
+		if err := validateImageReference(reference); err != nil {
+			t.Errorf("expected %q to be valid, got: %s", reference, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"Postgres:16",
+		"postgres:",
+		"postgres:-16",
+		"registry.example.com/:tag",
+		"postgres 16",
+		"mysql@sha256:1234",
+	}
+	for _, reference := range invalid {
+		if err := validateImageReference(reference); err == nil {
+			t.Errorf("expected %q to be invalid", reference)
+		}
+	}
+}
+
+func TestImageTagAndQualifyImage(t *testing.T) {
+	tags := map[string]string{
+		"postgres":                             "latest",
+		"registry.example.com:5000/agent":      "latest",
+		"registry.example.com:5000/agent:7-rc": "7-rc",
+	}
+	for reference, expected := range tags {
+		if got := imageTag(reference); got != expected {
+			t.Errorf("imageTag(%q) = %q, expected %q", reference, got, expected)
+		}
+	}
+
+	qualified := map[string]string{
+		"postgres:16":                "docker.io/library/postgres:16",
+		"datadog/agent:7":            "docker.io/datadog/agent:7",
+		"gcr.io/datadoghq/agent:7":   "gcr.io/datadoghq/agent:7",
+		"localhost/agent:7":          "localhost/agent:7",
+		"registry:5000/mirror/mysql": "registry:5000/mirror/mysql",
+	}
+	for reference, expected := range qualified {
+		if got := qualifyImage(reference); got != expected {
+			t.Errorf("qualifyImage(%q) = %q, expected %q", reference, got, expected)
+		}
+	}
+}
+
+func TestDockerCustomImages(t *testing.T) {
+	tests := []struct {
+		name    string
+		answers []string
+		agent   string
+		db      string
+	}{
+		{
+			name:    "curated",
+			answers: []string{"sandbox", "7.60.0", postgres, "16", noScenario, no},
+			agent:   "gcr.io/datadoghq/agent:7.60.0",
+			db:      "postgres:16",
+		},
+		{
+			name:    "custom",
+			answers: []string{"sandbox", customImage, "registry.example.com/agent:7.61.0-rc.1", mysql, customImage, "registry.example.com/mysql:8.4", noScenario, no},
+			agent:   "registry.example.com/agent:7.61.0-rc.1",
+			db:      "registry.example.com/mysql:8.4",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dp := GetDockerProvider()
+
+			asked := 0
+			for _, questionFunc := range dp.GetProviderQuestions() {
+				question := questionFunc()
+				if question == nil {
+					continue
+				}
+				question.Answer = test.answers[asked]
+				asked++
+			}
+			if asked != len(test.answers) {
+				t.Fatalf("the provider asked %d questions, expected %d", asked, len(test.answers))
+			}
+
+			dp.fillTemplateData("key")
+			if dp.templateData.Agent.Image != test.agent {
+				t.Errorf("the agent image is %q, expected %q", dp.templateData.Agent.Image, test.agent)
+			}
+			if dp.templateData.DB.Image != test.db {
+				t.Errorf("the DBMS image is %q, expected %q", dp.templateData.DB.Image, test.db)
+			}
+		})
+	}
+}
@@ -0,0 +1,286 @@
+package providers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	KUBERNETES = "Kubernetes"
+
+	// The ways the agent can be deployed.
+	datadogOperator = "Datadog Operator"
+	helmChart       = "Helm chart"
+
+	// The ways the DBM check can be configured.
+	clusterCheck            = "Cluster check"
+	autodiscoveryAnnotation = "Autodiscovery annotations"
+)
+
+var (
+	// The indexes of where the Kubernetes specific provider questions can be
+	// found, set when the questions are generated.
+	KubernetesDeploymentIndex  uint8
+	KubernetesCheckConfigIndex uint8
+	KubernetesKindIndex        uint8
+)
+
+// KubernetesProvider implements the Provider Interface and generates the
+// manifests needed to run the DBMS on Kubernetes, monitored by the Datadog
+// Agent deployed with the Datadog Operator or the Helm chart.
+type KubernetesProvider struct {
+	eventEmitter
+	questionnaire
+
+	// supportedDBMS is a slice of names for all of the DBMS's that this provider
+	// supports.
+	supportedDBMS []string
+
+	// templatePath is the location in the templateFS where the templates for
+	// this provider are located.
+	templatePath string
+	// dbmsTemplatePath is the location in the templateFS of the DBMS
+	// configuration files, shared with the DockerProvider.
+	dbmsTemplatePath string
+	// templateData is the data required to fill the project template files for
+	// this provider.
+	templateData kubernetesTemplateData
+	// templateFS is where the template files for this provider are located.
+	templateFS fs.FS
+}
+
+// kubernetesTemplateData is used to contain the data for the
+// KubernetesProvider template files.
+type kubernetesTemplateData struct {
+	Agent agentTemplateData
+	DB    dbTemplateData
+
+	// Namespace is the namespace everything is deployed in, it is also used as
+	// the cluster name.
+	Namespace string
+	// Service is the name of the DBMS Service and StatefulSet.
+	Service string
+	// Check is the name of the agent integration check for the DBMS.
+	Check string
+	// Port is the port the DBMS listens on.
+	Port int
+	// Files holds the DBMS configuration files, stored in a ConfigMap.
+	Files []kubernetesFile
+	// AgentConf is the agent integration configuration for the DBMS.
+	AgentConf string
+	// ADChecks is the agent integration configuration for the DBMS as the
+	// JSON used in Autodiscovery annotations.
+	ADChecks string
+	// Helm is true when the agent is deployed using the Helm chart, otherwise
+	// the Datadog Operator is used.
+	Helm bool
+	// ClusterCheck is true when the DBM check runs as a cluster check,
+	// otherwise it is configured using Autodiscovery annotations.
+	ClusterCheck bool
+	// Kind is true when a kind cluster configuration is generated.
+	Kind bool
+}
+
+// kubernetesFile is a DBMS configuration file stored in a ConfigMap.
+type kubernetesFile struct {
+	// Name is the key of the file in the ConfigMap.
+	Name string
+	// MountPath is where the file is mounted in the DBMS container.
+	MountPath string
+	// Content is the content of the file.
+	Content string
+}
+
+// GetKubernetesProvider will initiallize a new KubernetesProvider instance and
+// return a pointer to it.
+func GetKubernetesProvider() *KubernetesProvider {
+	kp := &KubernetesProvider{
+		supportedDBMS:    []string{postgres, mysql, sqlserver},
+		templatePath:     "embed/kubernetes/",
+		dbmsTemplatePath: "embed/docker/",
+		templateFS:       templateFS,
+	}
+	kp.generateProviderQuestions()
+
+	return kp
+}
+
+// generateProviderQuestions generates all of the questions that are required
+// to fill the providers template data.
+func (k *KubernetesProvider) generateProviderQuestions() {
+	k.addCommonQuestions(k.supportedDBMS)
+
+	agentDeployment := func() *Question {
+		question := &Question{
+			QType:   Picker,
+			Prompt:  "How would you like to deploy the Datadog Agent?",
+			Options: []string{datadogOperator, helmChart},
+			OptionDescriptions: []string{
+				"Creates a DatadogAgent resource",
+				"Creates a values.yaml for the datadog chart",
+			},
+			DefaultAnswer: datadogOperator,
+		}
+		k.QuestionAnswers = append(k.QuestionAnswers, question)
+
+		return question
+	}
+
+	checkConfig := func() *Question {
+		question := &Question{
+			QType:   Picker,
+			Prompt:  "How should the DBM check be configured?",
+			Options: []string{clusterCheck, autodiscoveryAnnotation},
+			OptionDescriptions: []string{
+				"Dispatched by the Cluster Agent",
+				"Annotations on the DBMS pod",
+			},
+			DefaultAnswer: clusterCheck,
+		}
+		k.QuestionAnswers = append(k.QuestionAnswers, question)
+
+		return question
+	}
+
+	kindCluster := func() *Question {
+		question := &Question{
+			QType:              Picker,
+			Prompt:             "Would you like to generate a kind cluster configuration?",
+			Options:            []string{no, yes},
+			OptionDescriptions: []string{"", "Creates kind-config.yaml to test the sandbox on a local cluster"},
+			DefaultAnswer:      no,
+		}
+		k.QuestionAnswers = append(k.QuestionAnswers, question)
+
+		return question
+	}
+
+	k.addQuestion(agentDeployment, &KubernetesDeploymentIndex)
+	k.addQuestion(checkConfig, &KubernetesCheckConfigIndex)
+	k.addQuestion(kindCluster, &KubernetesKindIndex)
+}
+
+// fillTemplateData will fill the KubernetesProvider.templateData with the
+// answers and the DBMS configuration files.
+func (k *KubernetesProvider) fillTemplateData(ddapikey string) error {
+	dbmsName := k.answer(DBMSIndex)
+	namespace := kubeName(k.answer(ProjectNameIndex))
+	dbmsDirectory := k.dbmsTemplatePath + strings.ToLower(dbmsName) + "/"
+
+	files := []kubernetesFile{}
+	for _, file := range dbmsFiles(dbmsName) {
+		content, err := fs.ReadFile(k.templateFS, dbmsDirectory+file.Source)
+		if err != nil {
+			return fmt.Errorf("Failed to read the DBMS file: %q, error: %q", file.Source, err)
+		}
+
+		files = append(files, kubernetesFile{
+			Name:      path.Base(file.Source),
+			MountPath: file.MountPath,
+			Content:   string(content),
+		})
+	}
+
+	agentConf, err := fs.ReadFile(k.templateFS, dbmsDirectory+"conf.d/"+agentConfDirectory(dbmsName)+"/conf.yaml")
+	if err != nil {
+		return fmt.Errorf("Failed to read the agent configuration for %q, error: %q", dbmsName, err)
+	}
+
+	host := fmt.Sprintf("%s.%s.svc.cluster.local", dbServiceName(dbmsName), namespace)
+
+	adChecks, err := autodiscoveryChecks(string(agentConf), dbmsName)
+	if err != nil {
+		return err
+	}
+
+	k.templateData = kubernetesTemplateData{
+		Agent: agentTemplateData{
+			Version:     k.answer(AgentVersionIndex),
+			DDAPIKey:    ddapikey,
+			ProjectName: k.answer(ProjectNameIndex),
+		},
+		DB: dbTemplateData{
+			DBMS:    dbmsName,
+			Version: k.answer(DBMSVersionIndex),
+		},
+		Namespace:    namespace,
+		Service:      dbServiceName(dbmsName),
+		Check:        agentCheckName(dbmsName),
+		Port:         dbPort(dbmsName),
+		Files:        files,
+		AgentConf:    replaceAgentHost(string(agentConf), dbmsName, host),
+		ADChecks:     adChecks,
+		Helm:         k.answer(KubernetesDeploymentIndex) == helmChart,
+		ClusterCheck: k.answer(KubernetesCheckConfigIndex) == clusterCheck,
+		Kind:         k.answer(KubernetesKindIndex) == yes,
+	}
+
+	return nil
+}
+
+// autodiscoveryChecks converts the agent integration configuration into the
+// JSON used by the ad.datadoghq.com/<container>.checks annotation, with the
+// host replaced by the %%host%% template variable.
+func autodiscoveryChecks(agentConf string, dbmsName string) (string, error) {
+	// %%host%% can't start a plain YAML scalar, so a placeholder is used while
+	// the configuration is parsed.
+	const hostPlaceholder = "autodiscovery-host"
+
+	conf := map[string]any{}
+	if err := yaml.Unmarshal([]byte(replaceAgentHost(agentConf, dbmsName, hostPlaceholder)), &conf); err != nil {
+		return "", fmt.Errorf("Failed to parse the agent configuration for %q, error: %q", dbmsName, err)
+	}
+
+	if conf["init_config"] == nil {
+		conf["init_config"] = map[string]any{}
+	}
+
+	checks, err := json.MarshalIndent(map[string]any{agentCheckName(dbmsName): conf}, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("Failed to encode the Autodiscovery annotation for %q, error: %q", dbmsName, err)
+	}
+
+	return strings.ReplaceAll(string(checks), hostPlaceholder, "%%host%%"), nil
+}
+
+// projectFiles returns the files that make up the project.
+func (k *KubernetesProvider) projectFiles() []projectFile {
+	files := []projectFile{
+		{template: k.templatePath + "README.md.tmpl", destination: "README.md"},
+		{template: k.templatePath + "namespace.yaml.tmpl", destination: "namespace.yaml"},
+		{template: k.templatePath + "datadog-secret.yaml.tmpl", destination: "datadog-secret.yaml"},
+		{template: k.templatePath + "database.yaml.tmpl", destination: "database.yaml"},
+	}
+
+	if k.templateData.Helm {
+		files = append(files, projectFile{template: k.templatePath + "values.yaml.tmpl", destination: "values.yaml"})
+	} else {
+		files = append(files, projectFile{template: k.templatePath + "datadog-agent.yaml.tmpl", destination: "datadog-agent.yaml"})
+	}
+
+	if k.templateData.Kind {
+		files = append(files, projectFile{template: k.templatePath + "kind-config.yaml.tmpl", destination: "kind-config.yaml"})
+	}
+
+	return files
+}
+
+// GenerateProject will generate the project directory on the users machine
+// with the Kubernetes manifests.
+func (k *KubernetesProvider) GenerateProject(ddapikey string) error {
+	if err := k.fillTemplateData(ddapikey); err != nil {
+		return err
+	}
+
+	projectName := k.templateData.Agent.ProjectName
+	if err := k.createProjectDirectory(projectName); err != nil {
+		return err
+	}
+
+	return k.renderProjectFiles(k.templateFS, projectName, k.projectFiles(), k.templateData)
+}
@@ -0,0 +1,94 @@
+package providers
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"path"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestKubernetesOperatorGolden(t *testing.T) {
+	runGoldenCases(t, []goldenCase{
+		{
+			name:     "kubernetes-operator-postgres",
+			provider: func() Provider { return GetKubernetesProvider() },
+			answers:  []string{"latest", postgres, "16", datadogOperator, clusterCheck, yes},
+		},
+		{
+			name:     "kubernetes-operator-mysql",
+			provider: func() Provider { return GetKubernetesProvider() },
+			answers:  []string{"7.60.0", mysql, "8.0.37", datadogOperator, autodiscoveryAnnotation, no},
+		},
+		{
+			name:     "kubernetes-operator-sqlserver",
+			provider: func() Provider { return GetKubernetesProvider() },
+			answers:  []string{"latest", sqlserver, "2022-latest", datadogOperator, clusterCheck, no},
+		},
+	}, checkKubernetesManifest)
+}
+
+func TestKubernetesHelmGolden(t *testing.T) {
+	runGoldenCases(t, []goldenCase{
+		{
+			name:     "kubernetes-helm-postgres",
+			provider: func() Provider { return GetKubernetesProvider() },
+			answers:  []string{"latest", postgres, "15", helmChart, autodiscoveryAnnotation, no},
+		},
+		{
+			name:     "kubernetes-helm-mysql",
+			provider: func() Provider { return GetKubernetesProvider() },
+			answers:  []string{"7.59.0", mysql, "8.0.35", helmChart, clusterCheck, yes},
+		},
+		{
+			name:     "kubernetes-helm-sqlserver",
+			provider: func() Provider { return GetKubernetesProvider() },
+			answers:  []string{"latest", sqlserver, "2019-latest", helmChart, autodiscoveryAnnotation, no},
+		},
+	}, checkKubernetesManifest)
+}
+
+// checkKubernetesManifest reports the YAML files that can't be parsed, every
+// document of a manifest must have a kind, except the Helm values and the kind
+// cluster configuration which must have their own kind.
+func checkKubernetesManifest(t *testing.T, file string, content []byte) {
+	t.Helper()
+
+	if path.Ext(file) != ".yaml" {
+		return
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for documents := 0; ; documents++ {
+		var document map[string]interface{}
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			if documents == 0 {
+				t.Errorf("%s: has no YAML document", file)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("%s: invalid YAML: %s", file, err)
+		}
+
+		switch file {
+		case "values.yaml":
+			if _, ok := document["datadog"]; !ok {
+				t.Errorf("%s: has no datadog section", file)
+			}
+
+		case "kind-config.yaml":
+			if document["kind"] != "Cluster" {
+				t.Errorf("%s: the kind is %v, expected Cluster", file, document["kind"])
+			}
+
+		default:
+			if kind, _ := document["kind"].(string); kind == "" || document["apiVersion"] == nil {
+				t.Errorf("%s: document %d has no kind or apiVersion", file, documents+1)
+			}
+		}
+	}
+}
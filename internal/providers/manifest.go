@@ -0,0 +1,109 @@
+package providers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestName is the file, in the project directory, that describes how the
+// compose projects were generated.
+const ManifestName = "dbm-sandbox.yaml"
+
+// A Manifest describes a generated compose project: the provider and images it
+// was generated with, the command that manages its containers and the files
+// that were written.
+type Manifest struct {
+	Provider string `yaml:"provider"`
+	// Compose is the compose command of the container engine, for example
+	// "docker compose".
+	Compose     string `yaml:"compose"`
+	AgentImage  string `yaml:"agent_image"`
+	DBMS        string `yaml:"dbms"`
+	DBMSVersion string `yaml:"dbms_version"`
+	DBMSImage   string `yaml:"dbms_image"`
+	// Pack is the template pack the project was generated with, if any.
+	Pack string `yaml:"pack,omitempty"`
+	// Files are the files of the project, relative to the project directory
+	// and sorted.
+	Files []string `yaml:"files"`
+}
+
+// ReadManifest returns the manifest of the project found in the directory, ok
+// is false when the project doesn't have one.
+func ReadManifest(dir string) (manifest Manifest, ok bool, err error) {
+	path := filepath.Join(dir, ManifestName)
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return manifest, false, nil
+	}
+	if err != nil {
+		return manifest, false, fmt.Errorf("Failed to read %q, error: %q", path, err)
+	}
+
+	if err := yaml.Unmarshal(content, &manifest); err != nil {
+		return manifest, false, fmt.Errorf("Failed to parse %q, error: %q", path, err)
+	}
+
+	return manifest, true, nil
+}
+
+// writeManifest lists the files of the generated project and writes its
+// manifest, it is the last file written to the project.
+func (d *DockerProvider) writeManifest() error {
+	projectName := d.templateData.Agent.ProjectName
+
+	return d.runStep("Write the manifest", func() error {
+		manifest := Manifest{
+			Provider:    d.name,
+			Compose:     strings.Join(d.starter.command, " "),
+			AgentImage:  d.templateData.Agent.Image,
+			DBMS:        d.templateData.DB.DBMS,
+			DBMSVersion: d.templateData.DB.Version,
+			DBMSImage:   d.templateData.DB.Image,
+			Pack:        d.templateData.Pack.Name,
+			Files:       []string{},
+		}
+
+		err := filepath.WalkDir(projectName, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() {
+				return err
+			}
+
+			relative, err := filepath.Rel(projectName, path)
+			if err != nil {
+				return err
+			}
+
+			manifest.Files = append(manifest.Files, filepath.ToSlash(relative))
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("Failed to list the files of %q, error: %q", projectName, err)
+		}
+		sort.Strings(manifest.Files)
+
+		// The lists are indented like in the templates
+		var content bytes.Buffer
+		encoder := yaml.NewEncoder(&content)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(manifest); err != nil {
+			return fmt.Errorf("Failed to encode the manifest of %q, error: %q", projectName, err)
+		}
+		encoder.Close()
+
+		path := filepath.Join(projectName, ManifestName)
+		if err := os.WriteFile(path, content.Bytes(), 0644); err != nil {
+			return fmt.Errorf("Failed to write to file: %q, error: %q", path, err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,147 @@
+package providers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	PODMAN = "Podman"
+
+	// The options of the question asking for the kube play manifest.
+	yes = "Yes"
+	no  = "No"
+)
+
+var (
+	// The index of where the Podman specific provider questions can be found,
+	// set when the questions are generated.
+	PodmanKubeIndex uint8
+
+	// invalidKubeName matches the characters that can't be used in the name
+	// of a Kubernetes object.
+	invalidKubeName = regexp.MustCompile(`[^a-z0-9-]+`)
+)
+
+// PodmanProvider implements the Provider Interface for Podman. It reuses the
+// DBMS templates of the DockerProvider with a compose file adapted to
+// Podman, and can also generate a manifest for "podman kube play".
+type PodmanProvider struct {
+	*DockerProvider
+
+	// kubeTemplate is the location in the templateFS of the template used to
+	// create the podman kube play manifest.
+	kubeTemplate string
+}
+
+// podmanKubeTemplateData is used to contain the data for the podman kube play
+// manifest. The manifest uses hostPath volumes which need absolute paths.
+type podmanKubeTemplateData struct {
+	dockerTemplateData
+	// ProjectPath is the absolute path of the project directory.
+	ProjectPath string
+	// ConfDirectory is the name of the agent conf.d directory of the DBMS.
+	ConfDirectory string
+}
+
+// GetPodmanProvider will initiallize a new PodmanProvider instance and return
+// a pointer to it.
+func GetPodmanProvider() *PodmanProvider {
+	// Dev Containers need the IDE to be set up for Podman, they aren't offered
+	dp := newDockerProvider("")
+	dp.name = PODMAN
+	dp.composeTemplate = "embed/podman/podman-compose.tmpl"
+	dp.containerSocket = podmanSocket()
+	dp.qualifyImages = true
+	dp.starter = composeStarter{command: []string{"podman", "compose"}}
+
+	pp := &PodmanProvider{
+		DockerProvider: dp,
+		kubeTemplate:   "embed/podman/kube.tmpl",
+	}
+	pp.generateProviderQuestions()
+
+	return pp
+}
+
+// podmanSocket returns the location of the Podman socket. Rootless Podman
+// keeps it in the runtime directory of the user.
+func podmanSocket() string {
+	uid := os.Getuid()
+	if uid <= 0 {
+		return "/run/podman/podman.sock"
+	}
+
+	return fmt.Sprintf("/run/user/%d/podman/podman.sock", uid)
+}
+
+// generateProviderQuestions adds the Podman specific questions after the
+// questions of the DockerProvider.
+func (p *PodmanProvider) generateProviderQuestions() {
+	kubeManifest := func() *Question {
+		question := &Question{
+			QType:              Picker,
+			Prompt:             "Would you like to also generate a podman kube play manifest?",
+			Options:            []string{no, yes},
+			OptionDescriptions: []string{"", "Creates kube.yaml to deploy the sandbox as a single pod"},
+			DefaultAnswer:      no,
+		}
+		p.QuestionAnswers = append(p.QuestionAnswers, question)
+
+		return question
+	}
+
+	p.addQuestion(kubeManifest, &PodmanKubeIndex)
+}
+
+// GenerateProject will generate the project directory on the users machine
+// using the templates and template data, and the kube play manifest when the
+// user asked for it, then write its manifest.
+func (p *PodmanProvider) GenerateProject(ddapikey string) error {
+	if err := p.generateProject(ddapikey); err != nil {
+		return err
+	}
+
+	if err := p.generateKubeManifest(); err != nil {
+		return err
+	}
+
+	return p.writeManifest()
+}
+
+// generateKubeManifest renders the kube play manifest when the user asked for
+// it.
+func (p *PodmanProvider) generateKubeManifest() error {
+	if p.QuestionAnswers[PodmanKubeIndex].Answer != yes {
+		return nil
+	}
+
+	projectPath, err := filepath.Abs(p.templateData.Agent.ProjectName)
+	if err != nil {
+		return fmt.Errorf("Failed to find the absolute path of the project, error: %q", err)
+	}
+
+	data := podmanKubeTemplateData{
+		dockerTemplateData: p.templateData,
+		ProjectPath:        filepath.ToSlash(projectPath),
+		ConfDirectory:      agentConfDirectory(p.templateData.DB.DBMS),
+	}
+
+	return p.renderProjectFiles(p.templateFS, p.templateData.Agent.ProjectName, []projectFile{
+		{template: p.kubeTemplate, destination: "kube.yaml"},
+	}, data)
+}
+
+// kubeName converts the name into a valid Kubernetes object name.
+func kubeName(name string) string {
+	name = invalidKubeName.ReplaceAllString(strings.ToLower(name), "-")
+	name = strings.Trim(name, "-")
+	if name == "" {
+		return "dbm-sandbox"
+	}
+
+	return name
+}
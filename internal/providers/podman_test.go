@@ -0,0 +1,107 @@
+package providers
+
+import (
+	"path"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// goldenPodmanSocket replaces the Podman socket, which depends on the user
+// running the tests.
+const goldenPodmanSocket = "/run/user/1000/podman/podman.sock"
+
+// goldenPodmanProvider returns a PodmanProvider using goldenPodmanSocket.
+func goldenPodmanProvider() Provider {
+	pp := GetPodmanProvider()
+	pp.containerSocket = goldenPodmanSocket
+
+	return pp
+}
+
+func TestPodmanGolden(t *testing.T) {
+	runGoldenCases(t, []goldenCase{
+		{
+			name:     "podman-postgres",
+			provider: goldenPodmanProvider,
+			answers:  []string{"latest", postgres, "16", noScenario, no},
+		},
+		{
+			name:     "podman-postgres-kube",
+			provider: goldenPodmanProvider,
+			answers:  []string{"7.60.0", postgres, "14", noScenario, yes},
+		},
+		{
+			name:     "podman-mysql",
+			provider: goldenPodmanProvider,
+			answers:  []string{"latest", mysql, "8.0.37", noScenario, no},
+		},
+		{
+			name:     "podman-mysql-kube",
+			provider: goldenPodmanProvider,
+			answers:  []string{customImage, "registry.example.com/agent:7.61.0-rc.1", mysql, customImage, "mirror/mysql:8.4", noScenario, yes},
+		},
+		{
+			name:     "podman-sqlserver",
+			provider: goldenPodmanProvider,
+			answers:  []string{"latest", sqlserver, "2022-latest", no},
+		},
+		{
+			name:     "podman-sqlserver-kube",
+			provider: goldenPodmanProvider,
+			answers:  []string{"7.59.0", sqlserver, "2019-latest", yes},
+		},
+	}, checkPodmanFile)
+}
+
+// checkPodmanFile checks the compose file like the Docker ones, and that the
+// kube play manifest is a valid pod.
+func checkPodmanFile(t *testing.T, file string, content []byte) {
+	t.Helper()
+
+	checkComposeFile(t, file, content)
+
+	if path.Base(file) != "kube.yaml" {
+		return
+	}
+
+	var pod struct {
+		Kind string `yaml:"kind"`
+		Spec struct {
+			Containers []struct {
+				Name  string `yaml:"name"`
+				Image string `yaml:"image"`
+			} `yaml:"containers"`
+		} `yaml:"spec"`
+	}
+	if err := yaml.Unmarshal(content, &pod); err != nil {
+		t.Fatalf("%s: invalid YAML: %s", file, err)
+	}
+
+	if pod.Kind != "Pod" || len(pod.Spec.Containers) != 2 {
+		t.Errorf("%s: expected a pod with 2 containers, got a %q with %d", file, pod.Kind, len(pod.Spec.Containers))
+	}
+
+	for _, container := range pod.Spec.Containers {
+		if qualifyImage(container.Image) != container.Image {
+			t.Errorf("%s: the image of %q isn't fully qualified: %q", file, container.Name, container.Image)
+		}
+	}
+}
+
+func TestKubeName(t *testing.T) {
+	names := map[string]string{
+		"sandbox":           "sandbox",
+		"My Sandbox":        "my-sandbox",
+		"dbm_sandbox.v2":    "dbm-sandbox-v2",
+		"--Sandbox--":       "sandbox",
+		"___":               "dbm-sandbox",
+		"Postgres 16 (dev)": "postgres-16-dev",
+	}
+
+	for name, expected := range names {
+		if got := kubeName(name); got != expected {
+			t.Errorf("kubeName(%q) = %q, expected %q", name, got, expected)
+		}
+	}
+}
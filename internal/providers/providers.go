@@ -1,14 +1,9 @@
 package providers
 
+import "context"
 
 const (
 	DOCKER = "Docker"
-
-	// In the future...
-	RDS    = "RDS"
-	AURORA = "Aurora"
-	AZURE  = "Azure"
-	GCP    = "GCP"
 )
 
 // A Provider handles gathering the information required for it to 
@@ -17,48 +12,81 @@ const (
 // GetProviderQuestions should provide the caller a slice of functions
 // that returns a pointer to a Question when executed. This allows the client
 // to interate over all of the questions that it needs to present to the user.
+// A function returns nil when its question doesn't apply to the previous
+// answers, the question must then be skipped.
 // 
 // GenerateProject should create a project directory on the users machine that
 // matches the required files that the provider needs to deploy the sandboxed 
 // environment. The name of the project directory should match the string 
 // passed to this function.
+//
+// SetEventHandler sets the function that is called with an Event each time
+// one of the steps the provider goes through changes status, this is what
+// drives the progress views.
 type Provider interface {
 	GetProviderQuestions() []func() *Question
 	GenerateProject(string) error
+	SetEventHandler(EventHandler)
+}
+
+// A Starter is a Provider that is also able to deploy the project it
+// generated, for example by starting its containers.
+//
+// StartProject should only be called after GenerateProject succeeded. It
+// stops the commands it runs when the context is cancelled.
+type Starter interface {
+	Provider
+	StartProject(ctx context.Context) error
+}
+
+// A DBMSLister is a Provider that can list the DBMS's, and the versions of
+// them, it is able to deploy.
+type DBMSLister interface {
+	Provider
+	SupportedDBMS() []DBMS
 }
 
-// Returns a list of the available providers that this tool currently supports.
-// Note that the order here matters and should match with the provider 
-// descriptions in the GetProviderDescriptions function.
+// Returns a list of the available providers that this tool currently supports,
+// in the order they were registered.
 func GetAvailableProviders() []string {
-	return []string{
-		DOCKER,
+	names := []string{}
+	for _, r := range registry {
+		names = append(names, r.Name)
 	}
+
+	return names
 }
 
-// Returns the description for each of the supported providers.
-// Note that the order here matters and should match with the providers returned
-// by the GetAvailableProviders function.
+// Returns the description for each of the providers returned by the
+// GetAvailableProviders function, in the same order.
 func GetProviderDescriptions() []string {
-	return []string{
-		"Uses Docker locally to create your project",
+	descriptions := []string{}
+	for _, r := range registry {
+		descriptions = append(descriptions, r.Description)
+	}
+
+	return descriptions
+}
 
-		// In the future...
-		// "Uses Amazon RDS in our Amazon Sandbox to create your project",
-		// "Uses Amazon Aurora in our Amazon Sandbox to create your project",
-		// "Uses our Microsoft Azure Sandbox to create your project",
-		// "Uses our Google Cloud Sandbox to create your project",
+// GetProviderQuestion returns the Question used to ask the user which provider
+// they would like to use, Docker being the default.
+func GetProviderQuestion() *Question {
+	return &Question{
+		QType:              Picker,
+		Prompt:             "What provider would you like to use?",
+		Options:            GetAvailableProviders(),
+		OptionDescriptions: GetProviderDescriptions(),
+		DefaultAnswer:      DOCKER,
 	}
 }
 
-// Returns the concrete implementation of the Provider Interface for the 
-// provider name passed in.
+// Returns the concrete implementation of the Provider Interface for the
+// provider name passed in. Returns nil if the provider isn't registered.
 func GetProvider(providerName string) Provider {
-	switch providerName {
-	case DOCKER:
-		return GetDockerProvider()
-
-	default:
+	r, ok := lookupRegistration(providerName)
+	if !ok {
 		return nil
 	}
+
+	return r.Factory()
 }
@@ -0,0 +1,222 @@
+package providers
+
+import "fmt"
+
+var (
+	// The indexes of where the common provider questions can be found, these
+	// are set when questions are generated. Every provider asks the common
+	// questions first so they are found at the same indexes.
+	ProjectNameIndex  uint8
+	AgentVersionIndex uint8
+	AgentImageIndex   uint8
+	DBMSIndex         uint8
+	DBMSVersionIndex  uint8
+	DBMSImageIndex    uint8
+)
+
+// questionnaire holds the questions of a provider and their answers. It can
+// be embedded in a Provider to implement the GetProviderQuestions method of
+// the Provider interface.
+type questionnaire struct {
+	// QuestionAnswers is a slice of *Question for this provider.
+	QuestionAnswers []*Question
+	// questionFuncs is a slice of functions that returns a *Question.
+	questionFuncs []func() *Question
+	// dbmsLookup returns the DBMS, and its versions, for the name picked by the
+	// user. GetDBMS is used when it isn't set.
+	dbmsLookup func(string) DBMS
+	// dbmsNames are the names of the DBMS's offered by the DBMS picker.
+	dbmsNames []string
+	// customImages adds a custom option to the agent and DBMS version
+	// pickers, for the providers that run them from container images, which
+	// lets the user enter any image reference.
+	customImages bool
+}
+
+// addCommonQuestions adds the questions every provider asks: the project name,
+// the agent version, the DBMS and the version of the DBMS. The image questions
+// are only asked when the custom option of a version picker is selected.
+func (q *questionnaire) addCommonQuestions(supportedDBMS []string) {
+	q.dbmsNames = supportedDBMS
+
+	projectName := func() *Question {
+		question := &Question{
+			QType:         Input,
+			Prompt:        "What is your project name?",
+			DefaultAnswer: "dbm-sandbox",
+		}
+
+		q.QuestionAnswers = append(q.QuestionAnswers, question)
+
+		return question
+	}
+
+	agentVersion := func() *Question {
+		versions, descriptions := catalog.agentVersions()
+		question := &Question{
+			QType:              Picker,
+			Prompt:             "What version of the agent would you like to use?",
+			Options:            versions,
+			OptionDescriptions: descriptions,
+			DefaultAnswer:      versions[0],
+		}
+		if q.customImages {
+			q.addCustomImageOption(question)
+		}
+		q.QuestionAnswers = append(q.QuestionAnswers, question)
+
+		return question
+	}
+	agentImageInput := func() *Question {
+		return q.imageQuestion(AgentVersionIndex, "What agent image would you like to use?", agentImage("latest"))
+	}
+	// The DBMS's and the versions the selected agent doesn't support are
+	// disabled.
+	dbmsPicker := func() *Question {
+		question := &Question{
+			QType:   Picker,
+			Prompt:  "What DBMS would you like to use?",
+			Options: supportedDBMS,
+		}
+
+		for _, name := range supportedDBMS {
+			supported, reason := q.lookupDBMS(name).supportedBy(q.agentVersion())
+			question.OptionDescriptions = append(question.OptionDescriptions, reason)
+			if !supported {
+				question.DisabledOptions = append(question.DisabledOptions, name)
+			}
+		}
+
+		q.QuestionAnswers = append(q.QuestionAnswers, question)
+
+		return question
+	}
+	dbmsVersionInput := func() *Question {
+		dbmsInfo := q.lookupDBMS(q.answer(DBMSIndex))
+
+		question := dbmsInfo.versionQuestion(q.agentVersion())
+		if q.customImages {
+			q.addCustomImageOption(question)
+		}
+		q.QuestionAnswers = append(q.QuestionAnswers, question)
+
+		return question
+	}
+	dbmsImageInput := func() *Question {
+		name := q.answer(DBMSIndex)
+		return q.imageQuestion(DBMSVersionIndex, fmt.Sprintf("What %s image would you like to use?", name), dbmsImage(name, "latest"))
+	}
+
+	q.addQuestion(projectName, &ProjectNameIndex)
+	q.addQuestion(agentVersion, &AgentVersionIndex)
+	q.addQuestion(agentImageInput, &AgentImageIndex)
+	q.addQuestion(dbmsPicker, &DBMSIndex)
+	q.addQuestion(dbmsVersionInput, &DBMSVersionIndex)
+	q.addQuestion(dbmsImageInput, &DBMSImageIndex)
+}
+
+// addCustomImageOption adds the custom option at the end of the version
+// picker.
+func (q *questionnaire) addCustomImageOption(question *Question) {
+	question.Options = append(question.Options, customImage)
+	question.OptionDescriptions = append(question.OptionDescriptions, "Enter an image reference, like registry/repository:tag")
+}
+
+// imageQuestion returns the question asking for the image reference when the
+// custom option of the version picker found at the index was selected, nil
+// otherwise. The question is always recorded so the indexes of the following
+// questions don't change.
+func (q *questionnaire) imageQuestion(versionIndex uint8, prompt string, defaultImage string) *Question {
+	question := &Question{
+		QType:         Input,
+		Prompt:        prompt,
+		DefaultAnswer: defaultImage,
+		Validate:      validateImageReference,
+	}
+	q.QuestionAnswers = append(q.QuestionAnswers, question)
+
+	if q.answer(versionIndex) != customImage {
+		return nil
+	}
+
+	return question
+}
+
+// agentVersion returns the version of the agent, the tag of the image when a
+// custom image is used.
+func (q *questionnaire) agentVersion() string {
+	if q.answer(AgentVersionIndex) == customImage {
+		return imageTag(q.answer(AgentImageIndex))
+	}
+
+	return q.answer(AgentVersionIndex)
+}
+
+// dbmsVersion returns the version of the DBMS, the tag of the image when a
+// custom image is used.
+func (q *questionnaire) dbmsVersion() string {
+	if q.answer(DBMSVersionIndex) == customImage {
+		return imageTag(q.answer(DBMSImageIndex))
+	}
+
+	return q.answer(DBMSVersionIndex)
+}
+
+// agentImage returns the image reference of the agent, the official image of
+// the selected version unless a custom image is used.
+func (q *questionnaire) agentImage() string {
+	if q.answer(AgentVersionIndex) == customImage {
+		return q.answer(AgentImageIndex)
+	}
+
+	return agentImage(q.answer(AgentVersionIndex))
+}
+
+// dbmsImage returns the image reference of the DBMS, the official image of
+// the selected version unless a custom image is used.
+func (q *questionnaire) dbmsImage() string {
+	if q.answer(DBMSVersionIndex) == customImage {
+		return q.answer(DBMSImageIndex)
+	}
+
+	return dbmsImage(q.answer(DBMSIndex), q.answer(DBMSVersionIndex))
+}
+
+// lookupDBMS returns the DBMS, and its versions, using the dbmsLookup of the
+// provider.
+func (q *questionnaire) lookupDBMS(DBMSName string) DBMS {
+	if q.dbmsLookup == nil {
+		return GetDBMS(DBMSName)
+	}
+
+	return q.dbmsLookup(DBMSName)
+}
+
+// SupportedDBMS returns the DBMS's the provider can deploy, with the versions
+// it supports, without asking any question.
+func (q *questionnaire) SupportedDBMS() []DBMS {
+	supported := []DBMS{}
+	for _, name := range q.dbmsNames {
+		supported = append(supported, q.lookupDBMS(name))
+	}
+
+	return supported
+}
+
+// addQuestion will set the proper index for the location of the question so
+// that they can be accessed correctly when filling the template data.
+func (q *questionnaire) addQuestion(fn func() *Question, indexVariable *uint8) {
+	*indexVariable = uint8(len(q.questionFuncs))
+	q.questionFuncs = append(q.questionFuncs, fn)
+}
+
+// GetProviderQuestions provides a slice of functions that return a *Question
+// for the provider.
+func (q *questionnaire) GetProviderQuestions() []func() *Question {
+	return q.questionFuncs
+}
+
+// answer returns the answer of the question found at the index.
+func (q *questionnaire) answer(index uint8) string {
+	return q.QuestionAnswers[index].Answer
+}
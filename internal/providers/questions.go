@@ -1,5 +1,7 @@
 package providers
 
+import "fmt"
+
 type QuestionType int
 
 const (
@@ -7,35 +9,77 @@ const (
 	Input
 )
 
-// A Question holds all the details relating to a question that a provide 
+// questionTypeNames are the names of the QuestionType values, used when a
+// Question is encoded, for example by external providers.
+var questionTypeNames = map[QuestionType]string{
+	Picker: "picker",
+	Input:  "input",
+}
+
+// MarshalText encodes the QuestionType using its name.
+func (t QuestionType) MarshalText() ([]byte, error) {
+	name, ok := questionTypeNames[t]
+	if !ok {
+		return nil, fmt.Errorf("Unknown question type: %d", t)
+	}
+
+	return []byte(name), nil
+}
+
+// UnmarshalText decodes a QuestionType from its name.
+func (t *QuestionType) UnmarshalText(text []byte) error {
+	for questionType, name := range questionTypeNames {
+		if name == string(text) {
+			*t = questionType
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Unknown question type: %q", text)
+}
+
+// A Question holds all the details relating to a question that a provide
 // needs a order to properly generate a working project.
 type Question struct {
 	// QType represents the type of question.
-	QType         QuestionType
+	QType QuestionType `json:"type"`
 
 	// Prompt is for the question that will be presented to the user.
-	Prompt        string
+	Prompt string `json:"prompt"`
 
-	// DefaultAnswer is the defualt answer for the question. It is only used for
-	// the Input Question Type since it's the only question type that accepts an
-	// empty answer from the user.
-	DefaultAnswer string
+	// DefaultAnswer is the defualt answer for the question. For the Input
+	// Question Type it is used when the user provides an empty answer, for the
+	// Picker Question Type it is the option that is highlighted by default.
+	DefaultAnswer string `json:"default,omitempty"`
 
 	// Options is where all of the available options for the question are kept.
 	// This is Only used for the QuestionType Picker
-	Options []string
+	Options []string `json:"options,omitempty"`
+
+	// OptionDescriptions holds an optional description for each of the Options,
+	// in the same order, for example "EOL" or "recommended". This is Only used
+	// for the QuestionType Picker.
+	OptionDescriptions []string `json:"option_descriptions,omitempty"`
+
+	// DisabledOptions holds the Options that are shown to the user but can't be
+	// selected, for example because they are not implemented yet. This is Only
+	// used for the QuestionType Picker.
+	DisabledOptions []string `json:"disabled_options,omitempty"`
 
 	// Answer is where the answer for the question will be placed.
-	Answer string
+	Answer string `json:"answer,omitempty"`
+
+	// Validate, when set, checks the answer of the user. The question is asked
+	// again while it returns an error.
+	Validate func(string) error `json:"-"`
 }
 
 // A RunnableQuestion is a question that can be ran to prompt the user for an
 // answer.
 //
-// Run should be have a *Question receiver and should prompt the user with the 
+// Run should be have a *Question receiver and should prompt the user with the
 // question, capture the response and load it into the *Question.Answer field
 // or return an error if an error occured.
 type RunnableQuestion interface {
 	Run() error
 }
-
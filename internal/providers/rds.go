@@ -0,0 +1,315 @@
+package providers
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+)
+
+const (
+	RDS = "RDS"
+)
+
+var (
+	// The indexes of where the AWS provider questions can be found, set when
+	// the questions are generated. They are shared by the RDS and Aurora
+	// providers.
+	AWSRegionIndex        uint8
+	AWSInstanceClassIndex uint8
+
+	// rdsInstanceClasses are the instance classes the database can use.
+	rdsInstanceClasses = []string{"db.t3.micro", "db.t3.medium", "db.m5.large"}
+	// rdsInstanceClassNotes holds the descriptions shown next to some of the
+	// rdsInstanceClasses.
+	rdsInstanceClassNotes = map[string]string{
+		"db.t3.micro":  "cheapest",
+		"db.t3.medium": "recommended",
+	}
+)
+
+// RDSProvider implements the Provider Interface and generates a Terraform
+// project that creates an Amazon RDS instance, configured for Database
+// Monitoring, and an EC2 instance running the Datadog Agent.
+type RDSProvider struct {
+	eventEmitter
+	questionnaire
+
+	// supportedDBMS is a slice of names for all of the DBMS's that this provider
+	// supports.
+	supportedDBMS []string
+
+	// templatePath is the location in the templateFS where the templates for
+	// this provider are located.
+	templatePath string
+	// awsTemplatePath is the location in the templateFS of the templates
+	// shared by the AWS providers.
+	awsTemplatePath string
+	// dbmsTemplatePath is the location in the templateFS of the DBMS
+	// configuration files, shared with the DockerProvider.
+	dbmsTemplatePath string
+	// templateData is the data required to fill the project template files for
+	// this provider.
+	templateData rdsTemplateData
+	// templateFS is where the template files for this provider are located.
+	templateFS fs.FS
+}
+
+// rdsTemplateData is used to contain the data for the RDSProvider template
+// files.
+type rdsTemplateData struct {
+	Agent agentTemplateData
+	DB    dbTemplateData
+
+	// Identifier is the name of the RDS instance, also used as the prefix of
+	// the other resources.
+	Identifier string
+	// Region is the AWS region the sandbox is created in.
+	Region string
+	// InstanceClass is the instance class of the RDS instance.
+	InstanceClass string
+	// Engine is the RDS engine of the DBMS.
+	Engine string
+	// ParameterGroupFamily is the family of the parameter group, which depends
+	// on the engine and its major version.
+	ParameterGroupFamily string
+	// Parameters are the DBM settings set in the parameter group.
+	Parameters []terraformParameter
+	// Port is the port the DBMS listens on.
+	Port int
+	// Check is the name of the agent integration check for the DBMS.
+	Check string
+	// ClientPackage is the package that installs the DBMS client on the agent
+	// host, used to run the bootstrap SQL.
+	ClientPackage string
+	// AgentMinorVersion is the minor version of the agent to install, empty to
+	// install the latest version.
+	AgentMinorVersion string
+	// AgentConf is the agent integration configuration for the DBMS.
+	AgentConf string
+	// AgentConfFile is the name of the file, in the conf.d directory of the
+	// check, the AgentConf is written to.
+	AgentConfFile string
+	// AgentDatadogConfig is added to the datadog.yaml of the agent, when it
+	// isn't empty.
+	AgentDatadogConfig string
+	// InitSQL is the SQL that creates the datadog user and its objects.
+	InitSQL string
+}
+
+// terraformParameter is a setting of a database parameter group.
+type terraformParameter struct {
+	Name        string
+	Value       string
+	ApplyMethod string
+}
+
+// GetRDSProvider will initiallize a new RDSProvider instance and return a
+// pointer to it.
+func GetRDSProvider() *RDSProvider {
+	rp := &RDSProvider{
+		supportedDBMS:    []string{postgres, mysql},
+		templatePath:     "embed/terraform/rds/",
+		awsTemplatePath:  "embed/terraform/aws/",
+		dbmsTemplatePath: "embed/docker/",
+		templateFS:       templateFS,
+	}
+	rp.generateProviderQuestions()
+
+	return rp
+}
+
+// generateProviderQuestions generates all of the questions that are required
+// to fill the providers template data.
+func (r *RDSProvider) generateProviderQuestions() {
+	r.addCommonQuestions(r.supportedDBMS)
+	r.addAWSQuestions(rdsInstanceClasses, rdsInstanceClassNotes, "db.t3.medium")
+}
+
+// addAWSQuestions adds the questions about the AWS region and the instance
+// class of the database, picked from the instanceClasses.
+func (q *questionnaire) addAWSQuestions(instanceClasses []string, notes map[string]string, defaultClass string) {
+	region := func() *Question {
+		question := &Question{
+			QType:         Input,
+			Prompt:        "What AWS region would you like to use?",
+			DefaultAnswer: "us-east-1",
+		}
+		q.QuestionAnswers = append(q.QuestionAnswers, question)
+
+		return question
+	}
+
+	instanceClass := func() *Question {
+		question := &Question{
+			QType:              Picker,
+			Prompt:             "What instance class would you like to use for the database?",
+			Options:            instanceClasses,
+			OptionDescriptions: describeOptions(instanceClasses, notes),
+			DefaultAnswer:      defaultClass,
+		}
+		q.QuestionAnswers = append(q.QuestionAnswers, question)
+
+		return question
+	}
+
+	q.addQuestion(region, &AWSRegionIndex)
+	q.addQuestion(instanceClass, &AWSInstanceClassIndex)
+}
+
+// fillTemplateData will fill the RDSProvider.templateData with the answers,
+// the DBMS init SQL and the agent configuration.
+func (r *RDSProvider) fillTemplateData(ddapikey string) error {
+	data, err := r.baseTemplateData(ddapikey)
+	if err != nil {
+		return err
+	}
+
+	data.AgentConf, err = cloudAgentConf(r.templateFS, r.dbmsTemplatePath, data.DB.DBMS, []agentInstance{{
+		Host:          "${db_host}",
+		Cloud:         "aws",
+		CloudSettings: [][2]string{{"instance_endpoint", "${db_host}"}, {"region", data.Region}},
+		Tags:          []string{"dbinstanceidentifier:" + data.Identifier},
+	}})
+	if err != nil {
+		return err
+	}
+
+	r.templateData = data
+	return nil
+}
+
+// baseTemplateData returns the template data shared by the AWS providers,
+// without the agent configuration.
+func (r *RDSProvider) baseTemplateData(ddapikey string) (rdsTemplateData, error) {
+	dbmsName := r.answer(DBMSIndex)
+
+	initSQL, err := readInitSQL(r.templateFS, r.dbmsTemplatePath, dbmsName)
+	if err != nil {
+		return rdsTemplateData{}, err
+	}
+
+	return rdsTemplateData{
+		Agent: agentTemplateData{
+			Version:     r.answer(AgentVersionIndex),
+			DDAPIKey:    ddapikey,
+			ProjectName: r.answer(ProjectNameIndex),
+		},
+		DB: dbTemplateData{
+			DBMS:    dbmsName,
+			Version: r.answer(DBMSVersionIndex),
+		},
+		Identifier:           cloudResourceName(r.answer(ProjectNameIndex)),
+		Region:               r.answer(AWSRegionIndex),
+		InstanceClass:        r.answer(AWSInstanceClassIndex),
+		Engine:               rdsEngine(dbmsName),
+		ParameterGroupFamily: rdsParameterGroupFamily(dbmsName, r.answer(DBMSVersionIndex)),
+		Parameters:           rdsParameters(dbmsName),
+		Port:                 dbPort(dbmsName),
+		Check:                agentCheckName(dbmsName),
+		ClientPackage:        dbClientPackage(dbmsName),
+		AgentMinorVersion:    agentMinorVersion(r.answer(AgentVersionIndex)),
+		AgentConfFile:        "conf.yaml",
+		InitSQL:              initSQL,
+	}, nil
+}
+
+// projectFiles returns the files that make up the project, the README,
+// main.tf and outputs.tf are specific to the provider while the other files
+// are shared by the AWS providers, or every Terraform provider.
+func (r *RDSProvider) projectFiles() []projectFile {
+	return []projectFile{
+		{template: r.templatePath + "README.md.tmpl", destination: "README.md"},
+		{template: r.awsTemplatePath + "versions.tf.tmpl", destination: "versions.tf"},
+		{template: r.awsTemplatePath + "variables.tf.tmpl", destination: "variables.tf"},
+		{template: r.templatePath + "main.tf.tmpl", destination: "main.tf"},
+		{template: r.templatePath + "outputs.tf.tmpl", destination: "outputs.tf"},
+		{template: terraformTemplatePath + "terraform.tfvars.tmpl", destination: "terraform.tfvars"},
+		{template: r.awsTemplatePath + "user-data.sh.tftpl.tmpl", destination: "user-data.sh.tftpl"},
+		{template: terraformTemplatePath + "bootstrap.sql.tmpl", destination: "bootstrap.sql"},
+	}
+}
+
+// GenerateProject will generate the project directory on the users machine
+// with the Terraform project.
+func (r *RDSProvider) GenerateProject(ddapikey string) error {
+	if err := r.fillTemplateData(ddapikey); err != nil {
+		return err
+	}
+
+	projectName := r.templateData.Agent.ProjectName
+	if err := r.createProjectDirectory(projectName); err != nil {
+		return err
+	}
+
+	return r.renderProjectFiles(r.templateFS, projectName, r.projectFiles(), r.templateData)
+}
+
+// rdsEngine returns the name of the RDS engine of the DBMS.
+func rdsEngine(DBMSName string) string {
+	switch DBMSName {
+	case mysql:
+		return "mysql"
+
+	default:
+		return "postgres"
+	}
+}
+
+// rdsParameterGroupFamily returns the parameter group family of the engine,
+// which uses the major version for Postgres and the major and minor versions
+// for MySQL.
+func rdsParameterGroupFamily(DBMSName string, version string) string {
+	parts := strings.Split(version, ".")
+
+	switch DBMSName {
+	case mysql:
+		if len(parts) > 1 {
+			return fmt.Sprintf("mysql%s.%s", parts[0], parts[1])
+		}
+		return "mysql" + parts[0]
+
+	default:
+		return "postgres" + parts[0]
+	}
+}
+
+// rdsParameters returns the parameters Database Monitoring needs for the
+// DBMS, as documented in the Datadog setup guides for RDS.
+func rdsParameters(DBMSName string) []terraformParameter {
+	switch DBMSName {
+	case mysql:
+		return []terraformParameter{
+			{Name: "performance_schema", Value: "1", ApplyMethod: "pending-reboot"},
+			{Name: "max_digest_length", Value: "4096", ApplyMethod: "pending-reboot"},
+			{Name: "performance_schema_max_digest_length", Value: "4096", ApplyMethod: "pending-reboot"},
+			{Name: "performance_schema_max_sql_text_length", Value: "4096", ApplyMethod: "pending-reboot"},
+			{Name: "performance-schema-consumer-events-statements-current", Value: "1", ApplyMethod: "pending-reboot"},
+			{Name: "performance-schema-consumer-events-statements-history", Value: "1", ApplyMethod: "pending-reboot"},
+			{Name: "performance-schema-consumer-events-statements-history-long", Value: "1", ApplyMethod: "pending-reboot"},
+			{Name: "performance-schema-consumer-events-waits-current", Value: "1", ApplyMethod: "pending-reboot"},
+		}
+
+	default:
+		return []terraformParameter{
+			{Name: "shared_preload_libraries", Value: "pg_stat_statements", ApplyMethod: "pending-reboot"},
+			{Name: "track_activity_query_size", Value: "4096", ApplyMethod: "pending-reboot"},
+			{Name: "pg_stat_statements.max", Value: "10000", ApplyMethod: "pending-reboot"},
+			{Name: "pg_stat_statements.track", Value: "ALL", ApplyMethod: "immediate"},
+			{Name: "pg_stat_statements.track_utility", Value: "0", ApplyMethod: "immediate"},
+			{Name: "track_io_timing", Value: "1", ApplyMethod: "immediate"},
+		}
+	}
+}
+
+// dbClientPackage returns the Amazon Linux package of the DBMS client that
+// is used to run the bootstrap SQL from the agent host. The newest client is
+// used since it can connect to every supported version.
+func dbClientPackage(DBMSName string) string {
+	switch DBMSName {
+	case mysql:
+		return "mariadb105"
+
+	default:
+		return "postgresql16"
+	}
+}
@@ -0,0 +1,143 @@
+package providers
+
+import "fmt"
+
+// A Registration holds everything the tool needs to know about a provider to
+// present it to the user and create it once selected.
+type Registration struct {
+	// Name is the name of the provider, it is the option shown to the user.
+	Name string
+
+	// Description is shown next to the Name when the user picks a provider.
+	Description string
+
+	// DBMS are the names of the DBMS's the provider can deploy, they list the
+	// supported versions without creating the provider. It is empty for the
+	// providers that can't list them, like the external providers.
+	DBMS []string
+	// DBMSLookup returns the DBMS, and the versions of it, the provider
+	// deploys. GetDBMS is used when it isn't set.
+	DBMSLookup func(string) DBMS
+
+	// Factory returns a new instance of the provider.
+	Factory func() Provider
+}
+
+// builtinProviders are the providers of this repository, in the order they
+// are offered: the local ones first, Docker being the default, then the cloud
+// ones.
+func builtinProviders() []Registration {
+	return []Registration{
+		{
+			Name:        DOCKER,
+			Description: "Uses Docker locally to create your project",
+			DBMS:        []string{postgres, mysql, sqlserver},
+			Factory:     func() Provider { return GetDockerProvider() },
+		},
+		{
+			Name:        PODMAN,
+			Description: "Uses rootless Podman and podman compose locally to create your project",
+			DBMS:        []string{postgres, mysql, sqlserver},
+			Factory:     func() Provider { return GetPodmanProvider() },
+		},
+		{
+			Name:        KUBERNETES,
+			Description: "Generates Kubernetes manifests using the Datadog Operator or Helm chart",
+			DBMS:        []string{postgres, mysql, sqlserver},
+			Factory:     func() Provider { return GetKubernetesProvider() },
+		},
+		{
+			Name:        VAGRANT,
+			Description: "Uses Vagrant to create a VM with the DBMS and agent installed from OS packages",
+			DBMS:        []string{postgres, mysql},
+			Factory:     func() Provider { return GetVagrantProvider() },
+		},
+		{
+			Name:        RDS,
+			Description: "Uses Amazon RDS in our Amazon Sandbox to create your project",
+			DBMS:        []string{postgres, mysql},
+			Factory:     func() Provider { return GetRDSProvider() },
+		},
+		{
+			Name:        AURORA,
+			Description: "Uses Amazon Aurora in our Amazon Sandbox to create your project",
+			DBMS:        []string{postgres, mysql},
+			DBMSLookup:  auroraDBMS,
+			Factory:     func() Provider { return GetAuroraProvider() },
+		},
+		{
+			Name:        AZURE,
+			Description: "Uses our Microsoft Azure Sandbox to create your project",
+			DBMS:        []string{postgres, mysql, sqlserver},
+			DBMSLookup:  azureDBMS,
+			Factory:     func() Provider { return GetAzureProvider() },
+		},
+		{
+			Name:        GCP,
+			Description: "Uses Cloud SQL in our Google Cloud Sandbox to create your project",
+			DBMS:        []string{postgres, mysql, sqlserver},
+			DBMSLookup:  cloudSQLDBMS,
+			Factory:     func() Provider { return GetGCPProvider() },
+		},
+	}
+}
+
+func init() {
+	for _, r := range builtinProviders() {
+		Register(r)
+	}
+}
+
+// registry holds every registered provider, in the order they were
+// registered.
+var registry []Registration
+
+// Register adds the provider to the registry. The built-in providers are
+// registered in the order of builtinProviders, the external providers after
+// them. Register panics if the registration is missing a name, a description
+// or a Factory, or if a provider with the same name is already registered.
+func Register(r Registration) {
+	if err := validateRegistration(r); err != nil {
+		panic(err)
+	}
+
+	registry = append(registry, r)
+}
+
+// validateRegistration checks that the registration can be added to the
+// registry.
+func validateRegistration(r Registration) error {
+	if r.Name == "" {
+		return fmt.Errorf("A provider must have a name")
+	}
+
+	if r.Description == "" {
+		return fmt.Errorf("The %q provider must have a description", r.Name)
+	}
+
+	if r.Factory == nil {
+		return fmt.Errorf("The %q provider must have a Factory", r.Name)
+	}
+
+	if _, ok := lookupRegistration(r.Name); ok {
+		return fmt.Errorf("A provider named %q is already registered", r.Name)
+	}
+
+	return nil
+}
+
+// Registrations returns every registered provider.
+func Registrations() []Registration {
+	return append([]Registration{}, registry...)
+}
+
+// lookupRegistration returns the registration of the provider with the name.
+func lookupRegistration(name string) (Registration, bool) {
+	for _, r := range registry {
+		if r.Name == name {
+			return r, true
+		}
+	}
+
+	return Registration{}, false
+}
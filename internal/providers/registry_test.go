@@ -0,0 +1,105 @@
+package providers
+
+import "testing"
+
+func TestProviderNamesAndDescriptionsMatch(t *testing.T) {
+	names := GetAvailableProviders()
+	descriptions := GetProviderDescriptions()
+
+	if len(names) == 0 {
+		t.Fatal("expected at least one available provider")
+	}
+
+	if len(names) != len(descriptions) {
+		t.Fatalf("got %d providers but %d descriptions", len(names), len(descriptions))
+	}
+
+	for ix, name := range names {
+		r, ok := lookupRegistration(name)
+		if !ok {
+			t.Fatalf("provider %q is not registered", name)
+		}
+
+		if descriptions[ix] != r.Description {
+			t.Errorf("description of %q is %q, expected %q", name, descriptions[ix], r.Description)
+		}
+	}
+}
+
+func TestRegistrationsAreUniqueAndDescribed(t *testing.T) {
+	seen := map[string]bool{}
+
+	for _, r := range Registrations() {
+		if r.Name == "" {
+			t.Error("found a provider without a name")
+		}
+
+		if r.Description == "" {
+			t.Errorf("provider %q has no description", r.Name)
+		}
+
+		if seen[r.Name] {
+			t.Errorf("provider %q is registered more than once", r.Name)
+		}
+		seen[r.Name] = true
+	}
+}
+
+func TestGetProvider(t *testing.T) {
+	for _, r := range Registrations() {
+		if GetProvider(r.Name) == nil {
+			t.Errorf("provider %q is registered but GetProvider returned nil", r.Name)
+		}
+	}
+
+	if GetProvider("not-a-provider") != nil {
+		t.Error("expected nil for an unknown provider")
+	}
+}
+
+func TestProviderQuestionListsDockerFirst(t *testing.T) {
+	question := GetProviderQuestion()
+
+	builtins := builtinProviders()
+	if len(question.Options) < len(builtins) {
+		t.Fatalf("got %d options, expected at least %d", len(question.Options), len(builtins))
+	}
+
+	for ix, r := range builtins {
+		if question.Options[ix] != r.Name {
+			t.Errorf("option %d is %q, expected %q", ix, question.Options[ix], r.Name)
+		}
+	}
+
+	if question.Options[0] != DOCKER || question.DefaultAnswer != DOCKER {
+		t.Errorf("the first option is %q and the default %q, expected %q", question.Options[0], question.DefaultAnswer, DOCKER)
+	}
+}
+
+func TestRegisterRejectsInvalidRegistrations(t *testing.T) {
+	saved := registry
+	defer func() { registry = saved }()
+
+	registry = nil
+	factory := func() Provider { return nil }
+	Register(Registration{Name: "Test", Description: "A test provider", Factory: factory})
+
+	tests := map[string]Registration{
+		"missing name":        {Description: "A test provider", Factory: factory},
+		"missing description": {Name: "Other", Factory: factory},
+		"missing factory":     {Name: "Other", Description: "A test provider"},
+		"duplicate name":      {Name: "Test", Description: "A duplicated test provider", Factory: factory},
+	}
+
+	for name, r := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected Register to panic")
+				}
+			}()
+
+			Register(r)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package providers
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/aldrickdev/dbm-sandbox/internal/utils/helpers"
+)
+
+// templateFuncs are the functions available in every template rendered with
+// renderTemplate.
+var templateFuncs = template.FuncMap{
+	"indent":   indent,
+	"kubeName": kubeName,
+}
+
+// A projectFile is a template of a provider and the location, relative to the
+// project directory, of the file it is rendered to.
+type projectFile struct {
+	template    string
+	destination string
+}
+
+// renderTemplate executes the template found at templatePath in the file
+// system with the data.
+func renderTemplate(fsys fs.FS, templatePath string, data any) ([]byte, error) {
+	temp, err := template.New(path.Base(templatePath)).Funcs(templateFuncs).ParseFS(fsys, templatePath)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse the template: %q, error: %q", templatePath, err)
+	}
+
+	var content bytes.Buffer
+	if err := temp.Execute(&content, data); err != nil {
+		return nil, fmt.Errorf("Failed to execute the template: %q, error: %q", templatePath, err)
+	}
+
+	return content.Bytes(), nil
+}
+
+// createProjectDirectory creates the project directory as a step, making sure
+// it doesn't already exist.
+func (e *eventEmitter) createProjectDirectory(directory string) error {
+	return e.runStep(fmt.Sprintf("Create directory %q", directory), func() error {
+		if err := helpers.CheckDirectory(directory); err != nil {
+			return err
+		}
+
+		return helpers.CreateDirectory(directory)
+	})
+}
+
+// renderProjectFiles renders each of the files into the project directory,
+// each file is its own step.
+func (e *eventEmitter) renderProjectFiles(fsys fs.FS, directory string, files []projectFile, data any) error {
+	for _, file := range files {
+		file := file
+		if err := e.runStep("Render "+file.destination, func() error {
+			content, err := renderTemplate(fsys, file.template, data)
+			if err != nil {
+				return err
+			}
+
+			destination := filepath.Join(directory, filepath.FromSlash(file.destination))
+			if err := os.MkdirAll(filepath.Dir(destination), 0755); err != nil {
+				return fmt.Errorf("Failed to create the directory for %q, error: %q", file.destination, err)
+			}
+
+			return helpers.WriteFile(destination, content)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// indent adds the number of spaces in front of every non empty line of the
+// text, which is needed to embed files in YAML block scalars.
+func indent(spaces int, text string) string {
+	prefix := strings.Repeat(" ", spaces)
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+
+	for ix, line := range lines {
+		if line != "" {
+			lines[ix] = prefix + line
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,80 @@
+package providers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aldrickdev/dbm-sandbox/internal/scenarios"
+)
+
+// scenarioDBMSVersions are the DBMS versions the scenarios are tested with.
+var scenarioDBMSVersions = map[string]string{postgres: "16", mysql: "8.0.37"}
+
+func TestDockerScenariosBreakTheChecks(t *testing.T) {
+	for _, DBMSName := range []string{postgres, mysql} {
+		t.Run(DBMSName, func(t *testing.T) {
+			generateGoldenProject(t, goldenCase{
+				name:     DBMSName,
+				provider: func() Provider { return GetDockerProvider() },
+				answers:  []string{"7.60.0", DBMSName, scenarioDBMSVersions[DBMSName], noScenario, no},
+			})
+
+			if _, applied, err := scenarios.Applied(goldenProjectName); err != nil || applied {
+				t.Fatalf("a scenario is applied to the sandbox, error: %v", err)
+			}
+
+			detected, err := scenarios.DetectDBMS(goldenProjectName)
+			if err != nil || detected != DBMSName {
+				t.Fatalf("detected %q, expected %q, error: %v", detected, DBMSName, err)
+			}
+
+			results := scenarios.RunChecks(goldenProjectName, DBMSName)
+			if len(results) == 0 {
+				t.Fatal("no check runs against the sandbox")
+			}
+			for _, result := range results {
+				if result.Err != nil {
+					t.Errorf("%q fails on the unbroken sandbox: %s", result.Check, result.Err)
+				}
+			}
+		})
+	}
+
+	for _, scenario := range scenarios.All() {
+		scenario := scenario
+		t.Run(scenario.Name, func(t *testing.T) {
+			generateGoldenProject(t, goldenCase{
+				name:     scenario.Name,
+				provider: func() Provider { return GetDockerProvider() },
+				answers:  []string{"7.60.0", scenario.DBMS, scenarioDBMSVersions[scenario.DBMS], scenario.Name, no},
+			})
+
+			applied, ok, err := scenarios.Applied(goldenProjectName)
+			if err != nil || !ok || applied.Name != scenario.Name {
+				t.Fatalf("the applied scenario is %q, expected %q, error: %v", applied.Name, scenario.Name, err)
+			}
+
+			marker, err := os.ReadFile(filepath.Join(goldenProjectName, scenarios.MarkerName))
+			if err != nil || strings.Contains(string(marker), scenario.Name) {
+				t.Errorf("the marker gives the scenario away: %q, error: %v", marker, err)
+			}
+
+			answer := applied.Answer("podman compose")
+			if strings.Contains(answer, "docker compose") || strings.Contains(answer, "{{") {
+				t.Errorf("the answer key doesn't use the compose command of the sandbox: %q", answer)
+			}
+
+			failed := 0
+			for _, result := range scenarios.RunChecks(goldenProjectName, scenario.DBMS) {
+				if result.Err != nil {
+					failed++
+				}
+			}
+			if failed == 0 {
+				t.Error("every check passes on the broken sandbox")
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package providers
+
+// A DBMSSupport lists the versions of a DBMS and the providers each of them
+// is available on.
+type DBMSSupport struct {
+	Name string `json:"name"`
+	// MinAgentVersion is the oldest agent that supports the DBMS, empty when
+	// any agent does.
+	MinAgentVersion string           `json:"min_agent_version,omitempty"`
+	Versions        []VersionSupport `json:"versions"`
+}
+
+// A VersionSupport is a version of a DBMS and the providers that can deploy
+// it.
+type VersionSupport struct {
+	Version string `json:"version"`
+	// Note is the description of the version, from the first provider that
+	// supports it.
+	Note string `json:"note,omitempty"`
+	// MinAgentVersion is the oldest agent that supports the version, empty
+	// when any agent supporting the DBMS does.
+	MinAgentVersion string   `json:"min_agent_version,omitempty"`
+	Providers       []string `json:"providers"`
+}
+
+// GetDBMSSupport returns every DBMS the available providers can deploy with
+// the versions they support, from their registrations, without creating
+// them. The catalog versions are listed first, followed by the versions
+// specific to some providers, like the Cloud SQL editions. Providers that
+// can't list their DBMS's, like the external providers, are skipped.
+func GetDBMSSupport() []DBMSSupport {
+	supports := []DBMSSupport{}
+	indexes := map[string]int{}
+
+	add := func(dbms DBMS, provider string) {
+		ix, ok := indexes[dbms.Name]
+		if !ok {
+			ix = len(supports)
+			indexes[dbms.Name] = ix
+			supports = append(supports, DBMSSupport{Name: dbms.Name, MinAgentVersion: dbms.MinAgentVersion()})
+		}
+
+		for _, version := range dbms.Versions() {
+			supports[ix].addVersion(dbms, version, provider)
+		}
+	}
+
+	for _, name := range []string{postgres, mysql, sqlserver} {
+		add(GetDBMS(name), "")
+	}
+
+	for _, r := range registry {
+		lookup := r.DBMSLookup
+		if lookup == nil {
+			lookup = GetDBMS
+		}
+
+		for _, name := range r.DBMS {
+			add(lookup(name), r.Name)
+		}
+	}
+
+	// The catalog versions no provider supports aren't deployable
+	for ix := range supports {
+		versions := []VersionSupport{}
+		for _, version := range supports[ix].Versions {
+			if len(version.Providers) > 0 {
+				versions = append(versions, version)
+			}
+		}
+		supports[ix].Versions = versions
+	}
+
+	return supports
+}
+
+// addVersion records that the provider supports the version of the DBMS, an
+// empty provider only adds the version.
+func (s *DBMSSupport) addVersion(dbms DBMS, version string, provider string) {
+	ix := -1
+	for vx := range s.Versions {
+		if s.Versions[vx].Version == version {
+			ix = vx
+			break
+		}
+	}
+
+	if ix == -1 {
+		ix = len(s.Versions)
+		s.Versions = append(s.Versions, VersionSupport{
+			Version:         version,
+			Note:            dbms.VersionNote(version),
+			MinAgentVersion: dbms.VersionMinAgentVersion(version),
+			Providers:       []string{},
+		})
+	}
+
+	if provider != "" {
+		s.Versions[ix].Providers = append(s.Versions[ix].Providers, provider)
+	}
+}
@@ -0,0 +1,81 @@
+package providers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDBMSSupportListsProvidersPerVersion(t *testing.T) {
+	supports := GetDBMSSupport()
+
+	find := func(dbms string, version string) VersionSupport {
+		t.Helper()
+
+		for _, support := range supports {
+			if support.Name != dbms {
+				continue
+			}
+
+			for _, v := range support.Versions {
+				if v.Version == version {
+					return v
+				}
+			}
+		}
+
+		t.Fatalf("%s %s is not listed", dbms, version)
+		return VersionSupport{}
+	}
+
+	postgres16 := find(postgres, "16")
+	for _, provider := range []string{DOCKER, VAGRANT, GCP} {
+		if !strings.Contains(strings.Join(postgres16.Providers, ","), provider) {
+			t.Errorf("expected Postgres 16 to be available on %s, got %v", provider, postgres16.Providers)
+		}
+	}
+
+	express := find(sqlserver, "2022_EXPRESS")
+	if len(express.Providers) != 1 || express.Providers[0] != GCP {
+		t.Errorf("expected SQL Server 2022_EXPRESS to only be available on GCP, got %v", express.Providers)
+	}
+
+	for _, support := range supports {
+		if support.MinAgentVersion != catalog.minAgentVersion(support.Name) {
+			t.Errorf("%s requires agent %q, expected %q", support.Name, support.MinAgentVersion, catalog.minAgentVersion(support.Name))
+		}
+
+		for _, version := range support.Versions {
+			if len(version.Providers) == 0 {
+				t.Errorf("%s %s isn't available on any provider", support.Name, version.Version)
+			}
+		}
+	}
+}
+
+func TestRegistrationsListTheDBMSOfTheirProvider(t *testing.T) {
+	for _, r := range builtinProviders() {
+		lister, ok := r.Factory().(DBMSLister)
+		if !ok {
+			t.Errorf("%s can't list the DBMS's it deploys", r.Name)
+			continue
+		}
+
+		lookup := r.DBMSLookup
+		if lookup == nil {
+			lookup = GetDBMS
+		}
+
+		supported := lister.SupportedDBMS()
+		if len(supported) != len(r.DBMS) {
+			t.Errorf("%s is registered with %v, the provider deploys %d DBMS's", r.Name, r.DBMS, len(supported))
+			continue
+		}
+
+		for ix, dbms := range supported {
+			registered := lookup(r.DBMS[ix])
+			if dbms.Name != registered.Name || strings.Join(dbms.Versions(), ",") != strings.Join(registered.Versions(), ",") {
+				t.Errorf("%s is registered with %s %v, the provider deploys %s %v", r.Name, registered.Name, registered.Versions(), dbms.Name, dbms.Versions())
+			}
+		}
+	}
+}
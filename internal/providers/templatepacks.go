@@ -0,0 +1,165 @@
+package providers
+
+import (
+	"io/fs"
+	"strings"
+
+	"github.com/aldrickdev/dbm-sandbox/internal/packs"
+)
+
+// noPack is the option of the pack picker used to not apply any pack.
+const noPack = "none"
+
+var (
+	// packsDir is the directory the template packs are installed in, no pack
+	// is offered when empty.
+	packsDir string
+
+	// The index of where the template pack question can be found, set when
+	// the questions are generated.
+	DockerPackIndex uint8
+)
+
+// SetPacksDir sets the directory the template packs are installed in. It
+// must be called before the providers are created.
+func SetPacksDir(dir string) {
+	packsDir = dir
+}
+
+// InstalledPacks returns the template packs installed in the packs directory,
+// and the errors of the packs that couldn't be loaded.
+func InstalledPacks() ([]packs.Pack, []error) {
+	if packsDir == "" {
+		return nil, nil
+	}
+
+	return packs.List(packsDir)
+}
+
+// packTemplateData is used to contain the template pack the user picked,
+// available to the templates as .Pack.
+type packTemplateData struct {
+	// Name is the name of the pack, empty when no pack is used.
+	Name string
+	// Version is the version of the pack.
+	Version string
+	// Answers holds the answers to the questions of the pack, by name.
+	Answers map[string]string
+}
+
+// addPackQuestions adds the template pack picker, skipped when no pack is
+// installed, followed by the questions of every pack, which are only asked
+// when their pack is picked.
+func (d *DockerProvider) addPackQuestions() {
+	d.installedPacks, _ = InstalledPacks()
+	d.packQuestionIndexes = map[string][]uint8{}
+
+	pack := func() *Question {
+		question := &Question{
+			QType:              Picker,
+			Prompt:             "Which template pack would you like to use?",
+			Options:            []string{noPack},
+			OptionDescriptions: []string{""},
+			DefaultAnswer:      noPack,
+		}
+
+		for _, p := range d.installedPacks {
+			if p.Supports(d.answer(DBMSIndex)) {
+				question.Options = append(question.Options, p.Name)
+				question.OptionDescriptions = append(question.OptionDescriptions, joinNotes(p.Version, p.Description))
+			}
+		}
+		d.QuestionAnswers = append(d.QuestionAnswers, question)
+
+		if len(question.Options) == 1 {
+			return nil
+		}
+
+		return question
+	}
+
+	d.addQuestion(pack, &DockerPackIndex)
+
+	for _, p := range d.installedPacks {
+		name := p.Name
+		for _, packQuestion := range p.Questions {
+			packQuestion := packQuestion
+			fn := func() *Question {
+				question := &Question{
+					QType:         Input,
+					Prompt:        packQuestion.Prompt,
+					DefaultAnswer: packQuestion.Default,
+				}
+				if packQuestion.Type == packs.Picker {
+					question.QType = Picker
+					question.Options = packQuestion.Options
+				}
+				d.QuestionAnswers = append(d.QuestionAnswers, question)
+
+				if d.answer(DockerPackIndex) != name {
+					return nil
+				}
+
+				return question
+			}
+
+			var index uint8
+			d.addQuestion(fn, &index)
+			d.packQuestionIndexes[name] = append(d.packQuestionIndexes[name], index)
+		}
+	}
+}
+
+// selectedPack returns the template pack the user picked, ok is false when
+// no pack is used.
+func (d *DockerProvider) selectedPack() (pack packs.Pack, ok bool) {
+	name := d.answer(DockerPackIndex)
+	for _, p := range d.installedPacks {
+		if p.Name == name {
+			return p, true
+		}
+	}
+
+	return pack, false
+}
+
+// packTemplateData returns the template pack the user picked and the answers
+// to its questions.
+func (d *DockerProvider) packTemplateData() packTemplateData {
+	pack, ok := d.selectedPack()
+	if !ok {
+		return packTemplateData{}
+	}
+
+	data := packTemplateData{
+		Name:    pack.Name,
+		Version: pack.Version,
+		Answers: map[string]string{},
+	}
+	for ix, index := range d.packQuestionIndexes[pack.Name] {
+		data.Answers[pack.Questions[ix].Name] = d.answer(index)
+	}
+
+	return data
+}
+
+// packFiles returns the files of the template pack that are rendered into the
+// project, their .tmpl suffix is removed.
+func packFiles(pack packs.Pack) ([]projectFile, error) {
+	fsys := pack.FilesFS()
+	if _, err := fs.Stat(fsys, "."); err != nil {
+		return nil, nil
+	}
+
+	files := []projectFile{}
+	err := fs.WalkDir(fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		files = append(files, projectFile{template: name, destination: strings.TrimSuffix(name, ".tmpl")})
+		return nil
+	})
+
+	return files, err
+}
@@ -0,0 +1,138 @@
+package providers
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aldrickdev/dbm-sandbox/internal/utils/helpers"
+)
+
+// templatesRoot is the directory of the templateFS every template is found
+// in, the templates directory of the user mirrors its content.
+const templatesRoot = "embed"
+
+// SetTemplatesDir overlays the templates found in the directory on top of the
+// embedded templates. A file found in the directory replaces the embedded
+// file at the same path, relative to the embed directory, and new files are
+// added. It must be called before the providers are created.
+func SetTemplatesDir(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("Failed to find the templates directory %q, error: %q", dir, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("The templates directory %q is not a directory", dir)
+	}
+
+	templateFS = overlayFS{
+		upper: mountFS{fsys: os.DirFS(dir), prefix: templatesRoot},
+		lower: embeddedTemplates,
+	}
+
+	return nil
+}
+
+// ExportTemplates writes the embedded templates to the directory, which must
+// not exist yet, as a starting point for a templates directory.
+func ExportTemplates(dir string) error {
+	if err := helpers.CheckDirectory(dir); err != nil {
+		return err
+	}
+
+	return fs.WalkDir(embeddedTemplates, templatesRoot, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		destination := filepath.Join(dir, filepath.FromSlash(strings.TrimPrefix(name, templatesRoot)))
+		if entry.IsDir() {
+			if err := os.MkdirAll(destination, 0755); err != nil {
+				return fmt.Errorf("Failed to create the directory %q, error: %q", destination, err)
+			}
+			return nil
+		}
+
+		content, err := fs.ReadFile(embeddedTemplates, name)
+		if err != nil {
+			return fmt.Errorf("Failed to read the template %q, error: %q", name, err)
+		}
+
+		return helpers.WriteFile(destination, content)
+	})
+}
+
+// overlayFS is a file system where the files of the upper file system replace
+// the files of the lower one, the content of their directories is merged.
+type overlayFS struct {
+	upper fs.FS
+	lower fs.FS
+}
+
+// Open opens the file of the upper file system when it has one, directories
+// are opened from the lower file system when they exist in both.
+func (o overlayFS) Open(name string) (fs.File, error) {
+	if file, err := o.upper.Open(name); err == nil {
+		if info, err := file.Stat(); err == nil && !info.IsDir() {
+			return file, nil
+		}
+		file.Close()
+	}
+
+	if file, err := o.lower.Open(name); err == nil {
+		return file, nil
+	}
+
+	return o.upper.Open(name)
+}
+
+// ReadDir returns the entries of the directory in both file systems, sorted by
+// name, the entries of the upper file system replace the ones with the same
+// name.
+func (o overlayFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	lower, lowerErr := fs.ReadDir(o.lower, name)
+	upper, upperErr := fs.ReadDir(o.upper, name)
+	if lowerErr != nil && upperErr != nil {
+		return nil, lowerErr
+	}
+
+	merged := map[string]fs.DirEntry{}
+	for _, entry := range lower {
+		merged[entry.Name()] = entry
+	}
+	for _, entry := range upper {
+		merged[entry.Name()] = entry
+	}
+
+	entries := []fs.DirEntry{}
+	for _, entry := range merged {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	return entries, nil
+}
+
+// mountFS exposes a file system under the prefix, the files outside of it
+// don't exist.
+type mountFS struct {
+	fsys   fs.FS
+	prefix string
+}
+
+// Open opens the file of the file system found at the name without the
+// prefix.
+func (m mountFS) Open(name string) (fs.File, error) {
+	if name == m.prefix {
+		return m.fsys.Open(".")
+	}
+
+	if rest, ok := strings.CutPrefix(name, m.prefix+"/"); ok && fs.ValidPath(rest) {
+		return m.fsys.Open(rest)
+	}
+
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
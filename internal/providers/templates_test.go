@@ -0,0 +1,142 @@
+package providers
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTemplatesDirOverlaysTheEmbeddedTemplates(t *testing.T) {
+	original := templateFS
+	t.Cleanup(func() { templateFS = original })
+
+	dir := t.TempDir()
+	writeTemplate(t, dir, "docker/postgres/postgres/postgresql.conf", "shared_preload_libraries = 'pg_stat_statements'\n")
+	writeTemplate(t, dir, "docker/postgres/NOTES.md", "Added by the templates directory\n")
+
+	if err := SetTemplatesDir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	project := generateGoldenProject(t, goldenCase{
+		name:     "docker-templates-dir",
+		provider: func() Provider { return GetDockerProvider() },
+		answers:  []string{"7.60.0", postgres, "16", noScenario, no},
+	})
+
+	if got := string(project["postgres/postgresql.conf"]); got != "shared_preload_libraries = 'pg_stat_statements'\n" {
+		t.Errorf("postgresql.conf wasn't replaced, got:\n%s", got)
+	}
+	if got := string(project["NOTES.md"]); got != "Added by the templates directory\n" {
+		t.Errorf("NOTES.md wasn't added, got:\n%s", got)
+	}
+
+	initSQL, err := fs.ReadFile(embeddedTemplates, "embed/docker/postgres/postgres/init.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(project["postgres/init.sql"], initSQL) {
+		t.Error("init.sql should be the embedded one")
+	}
+}
+
+func TestSetTemplatesDirRequiresADirectory(t *testing.T) {
+	original := templateFS
+	t.Cleanup(func() { templateFS = original })
+
+	if err := SetTemplatesDir(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected an error for a missing directory")
+	}
+}
+
+func TestExportTemplates(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "templates")
+	if err := ExportTemplates(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	err := fs.WalkDir(embeddedTemplates, templatesRoot, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		expected, err := fs.ReadFile(embeddedTemplates, name)
+		if err != nil {
+			return err
+		}
+
+		exported, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name[len(templatesRoot):])))
+		if err != nil {
+			return err
+		}
+
+		if !bytes.Equal(exported, expected) {
+			t.Errorf("the exported %s differs from the embedded one", name)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ExportTemplates(dir); err == nil {
+		t.Error("expected an error when exporting to an existing directory")
+	}
+}
+
+// writeTemplate writes the file to the templates directory, creating its
+// parent directories.
+func writeTemplate(t *testing.T, dir string, name string, content string) {
+	t.Helper()
+
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDockerTemplatePack(t *testing.T) {
+	t.Cleanup(func() { SetPacksDir("") })
+
+	dir := t.TempDir()
+	SetPacksDir(dir)
+	writeTemplate(t, dir, "pg-high-bloat/pack.yaml", `name: pg-high-bloat
+version: 1.0.0
+description: Tables with a lot of dead tuples
+dbms: [Postgres]
+questions:
+- name: table_count
+  prompt: How many bloated tables?
+  default: "10"
+`)
+	writeTemplate(t, dir, "pg-high-bloat/files/postgres/bloat.sql.tmpl", "-- {{ .Pack.Name }} {{ .Pack.Version }} with {{ .Pack.Answers.table_count }} tables\n")
+	writeTemplate(t, dir, "pg-high-bloat/templates/docker/postgres/postgres/postgresql.conf", "autovacuum = off\n")
+
+	project := generateGoldenProject(t, goldenCase{
+		name:     "docker-pack",
+		provider: func() Provider { return GetDockerProvider() },
+		answers:  []string{"7.60.0", postgres, "16", "pg-high-bloat", "25", noScenario, no},
+	})
+
+	if got := string(project["postgres/bloat.sql"]); got != "-- pg-high-bloat 1.0.0 with 25 tables\n" {
+		t.Errorf("the pack file wasn't rendered, got:\n%s", got)
+	}
+	if got := string(project["postgres/postgresql.conf"]); got != "autovacuum = off\n" {
+		t.Errorf("the pack template wasn't applied, got:\n%s", got)
+	}
+
+	// The pack doesn't support MySQL so the picker is skipped
+	project = generateGoldenProject(t, goldenCase{
+		name:     "docker-pack-mysql",
+		provider: func() Provider { return GetDockerProvider() },
+		answers:  []string{"7.60.0", mysql, "8.0.37", noScenario, no},
+	})
+	if _, ok := project["postgres/bloat.sql"]; ok {
+		t.Error("the pack shouldn't be applied to MySQL")
+	}
+}
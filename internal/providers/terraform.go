@@ -0,0 +1,238 @@
+package providers
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// terraformTemplatePath is the location in the templateFS of the templates
+	// shared by every provider that generates a Terraform project.
+	terraformTemplatePath = "embed/terraform/"
+
+	// sqlServerMonitorPassword is the password of the datadog login created on
+	// the managed SQL Server databases, which enforce a password policy.
+	sqlServerMonitorPassword = "Datadog-Sandbox-1"
+)
+
+var (
+	// repeatedHyphens matches the hyphens that the cloud providers don't allow
+	// to follow each other in resource names.
+	repeatedHyphens = regexp.MustCompile(`-{2,}`)
+)
+
+// An agentInstance is an instance of the agent integration configuration of a
+// DBMS running on a cloud provider.
+type agentInstance struct {
+	// Host is the host the DBMS is reachable at.
+	Host string
+	// Port replaces the port of the configuration when it isn't empty, for
+	// example with an Autodiscovery template variable.
+	Port string
+	// Settings are set on the instance as key and value pairs, replacing the
+	// values of the configuration, for example the credentials.
+	Settings [][2]string
+	// Cloud is the key of the cloud specific settings, for example aws.
+	Cloud string
+	// CloudSettings are the cloud specific settings of the instance, as key and
+	// value pairs, in order.
+	CloudSettings [][2]string
+	// Tags are the tags added to everything the instance reports.
+	Tags []string
+}
+
+// readDBMSFile reads a configuration file of the DBMS from the directory of
+// the DBMS in the docker embed tree, which every provider shares.
+func readDBMSFile(fsys fs.FS, dbmsTemplatePath string, DBMSName string, source string) (string, error) {
+	content, err := fs.ReadFile(fsys, dbmsTemplatePath+strings.ToLower(DBMSName)+"/"+source)
+	if err != nil {
+		return "", fmt.Errorf("Failed to read the DBMS file: %q, error: %q", source, err)
+	}
+
+	return string(content), nil
+}
+
+// readInitSQL reads the init SQL of the DBMS, used to bootstrap the datadog
+// user on the managed databases.
+func readInitSQL(fsys fs.FS, dbmsTemplatePath string, DBMSName string) (string, error) {
+	file, ok := dbmsInitSQL(DBMSName)
+	if !ok {
+		return "", fmt.Errorf("There is no init SQL for %q", DBMSName)
+	}
+
+	return readDBMSFile(fsys, dbmsTemplatePath, DBMSName, file.Source)
+}
+
+// cloudAgentConf reads the agent integration configuration of the DBMS and
+// returns it with one instance for each of the agentInstances, keeping the
+// credentials and options of the original configuration.
+func cloudAgentConf(fsys fs.FS, dbmsTemplatePath string, DBMSName string, instances []agentInstance) (string, error) {
+	document, err := cloudAgentDocument(fsys, dbmsTemplatePath, DBMSName, instances)
+	if err != nil {
+		return "", err
+	}
+
+	return encodeYAML(document, DBMSName)
+}
+
+// cloudAgentDocument is like cloudAgentConf but returns the parsed YAML
+// document, so callers can add settings to it before it is encoded.
+func cloudAgentDocument(fsys fs.FS, dbmsTemplatePath string, DBMSName string, instances []agentInstance) (*yaml.Node, error) {
+	conf, err := readDBMSFile(fsys, dbmsTemplatePath, DBMSName, "conf.d/"+agentConfDirectory(DBMSName)+"/conf.yaml")
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := parseAgentConf(conf, DBMSName)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := mappingValue(document.Content[0], "instances")
+	nodes.Content = nil
+	for _, instance := range instances {
+		node, err := cloudAgentInstance(conf, DBMSName, instance)
+		if err != nil {
+			return nil, err
+		}
+
+		nodes.Content = append(nodes.Content, node)
+	}
+
+	return document, nil
+}
+
+// parseAgentConf parses the agent integration configuration of the DBMS and
+// makes sure it has instances.
+func parseAgentConf(conf string, DBMSName string) (*yaml.Node, error) {
+	document := &yaml.Node{}
+	if err := yaml.Unmarshal([]byte(conf), document); err != nil {
+		return nil, fmt.Errorf("Failed to parse the agent configuration for %q, error: %q", DBMSName, err)
+	}
+
+	if len(document.Content) == 0 {
+		return nil, fmt.Errorf("The agent configuration for %q is empty", DBMSName)
+	}
+
+	nodes := mappingValue(document.Content[0], "instances")
+	if nodes == nil || nodes.Kind != yaml.SequenceNode || len(nodes.Content) == 0 {
+		return nil, fmt.Errorf("The agent configuration for %q has no instances", DBMSName)
+	}
+
+	return document, nil
+}
+
+// cloudAgentInstance returns the first instance of the agent integration
+// configuration with the host, port, cloud settings and tags of the instance.
+func cloudAgentInstance(conf string, DBMSName string, instance agentInstance) (*yaml.Node, error) {
+	document, err := parseAgentConf(conf, DBMSName)
+	if err != nil {
+		return nil, err
+	}
+
+	node := mappingValue(document.Content[0], "instances").Content[0]
+
+	// The host is replaced in the parsed configuration since template
+	// variables like %%host%% aren't valid plain YAML scalars. SQL Server
+	// keeps the port after the host.
+	if host := mappingValue(node, "host"); host != nil {
+		host.Value = instance.Host + strings.TrimPrefix(host.Value, dbServiceName(DBMSName))
+		host.Tag = "!!str"
+		host.Style = 0
+	}
+
+	if port := mappingValue(node, "port"); port != nil && instance.Port != "" {
+		port.Value = instance.Port
+		port.Tag = "!!str"
+	}
+
+	for _, setting := range instance.Settings {
+		if value := mappingValue(node, setting[0]); value != nil {
+			*value = *scalarNode(setting[1])
+			continue
+		}
+		node.Content = append(node.Content, scalarNode(setting[0]), scalarNode(setting[1]))
+	}
+
+	if instance.Cloud != "" {
+		settings := &yaml.Node{Kind: yaml.MappingNode}
+		for _, setting := range instance.CloudSettings {
+			settings.Content = append(settings.Content, scalarNode(setting[0]), scalarNode(setting[1]))
+		}
+		node.Content = append(node.Content, scalarNode(instance.Cloud), settings)
+	}
+
+	if len(instance.Tags) > 0 {
+		tags := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, tag := range instance.Tags {
+			tags.Content = append(tags.Content, scalarNode(tag))
+		}
+		node.Content = append(node.Content, scalarNode("tags"), tags)
+	}
+
+	return node, nil
+}
+
+// encodeYAML encodes the YAML document of the agent configuration with the
+// indentation used by the agent documentation.
+func encodeYAML(document *yaml.Node, DBMSName string) (string, error) {
+	var content bytes.Buffer
+	encoder := yaml.NewEncoder(&content)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(document); err != nil {
+		return "", fmt.Errorf("Failed to encode the agent configuration for %q, error: %q", DBMSName, err)
+	}
+
+	return content.String(), nil
+}
+
+// mappingValue returns the value of the key in the YAML mapping, or nil when
+// the key isn't found.
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for ix := 0; ix+1 < len(mapping.Content); ix += 2 {
+		if mapping.Content[ix].Value == key {
+			return mapping.Content[ix+1]
+		}
+	}
+
+	return nil
+}
+
+// scalarNode returns a YAML string node.
+func scalarNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+// cloudResourceName converts the project name into a name that is valid for
+// the databases of every cloud provider: it must start with a letter and
+// can't contain two hyphens in a row. It is kept short enough to be used as
+// the prefix of the other resources.
+func cloudResourceName(name string) string {
+	name = repeatedHyphens.ReplaceAllString(kubeName(name), "-")
+	if name[0] < 'a' || name[0] > 'z' {
+		name = "dbm-" + name
+	}
+
+	if len(name) > 40 {
+		name = strings.TrimRight(name[:40], "-")
+	}
+
+	return name
+}
+
+// agentMinorVersion returns the minor version of the agent, as expected by
+// the DD_AGENT_MINOR_VERSION variable of the install script, or an empty
+// string when the latest version should be installed.
+func agentMinorVersion(version string) string {
+	major, minor, found := strings.Cut(version, ".")
+	if !found || major != "7" {
+		return ""
+	}
+
+	return minor
+}
@@ -0,0 +1,201 @@
+package providers
+
+import (
+	"path"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// terraformAttribute matches a single line attribute, or object key, and
+// captures its indentation and name.
+var terraformAttribute = regexp.MustCompile(`^(\s*)("[^"]*"|[\w-]+)(\s*)= `)
+
+func TestRDSGolden(t *testing.T) {
+	runGoldenCases(t, []goldenCase{
+		{
+			name:     "rds-postgres",
+			provider: func() Provider { return GetRDSProvider() },
+			answers:  []string{"latest", postgres, "16", "us-east-1", "db.t3.medium"},
+		},
+		{
+			name:     "rds-mysql",
+			provider: func() Provider { return GetRDSProvider() },
+			answers:  []string{"7.58.0", mysql, "8.0.37", "eu-west-1", "db.t3.micro"},
+		},
+	}, checkTerraformFormat)
+}
+
+func TestAuroraGolden(t *testing.T) {
+	runGoldenCases(t, []goldenCase{
+		{
+			name:     "aurora-postgres-autodiscovery",
+			provider: func() Provider { return GetAuroraProvider() },
+			answers:  []string{"latest", postgres, "16.4", "us-east-1", "db.t3.medium", "1", auroraAutodiscovery},
+		},
+		{
+			name:     "aurora-mysql-explicit",
+			provider: func() Provider { return GetAuroraProvider() },
+			answers:  []string{"7.58.0", mysql, "8.0.mysql_aurora.3.07.1", "eu-west-1", "db.r6g.large", "2", auroraExplicit},
+		},
+	}, checkTerraformFormat)
+}
+
+func TestAzureGolden(t *testing.T) {
+	runGoldenCases(t, []goldenCase{
+		{
+			name:     "azure-postgres",
+			provider: func() Provider { return GetAzureProvider() },
+			answers:  []string{"latest", postgres, "16", "eastus", "B_Standard_B2s"},
+		},
+		{
+			name:     "azure-mysql",
+			provider: func() Provider { return GetAzureProvider() },
+			answers:  []string{"7.58.0", mysql, "8.0.21", "westeurope", "GP_Standard_D2ds_v4"},
+		},
+		{
+			name:     "azure-sqlserver",
+			provider: func() Provider { return GetAzureProvider() },
+			answers:  []string{"latest", sqlserver, "12.0", "eastus", "S0"},
+		},
+	}, checkTerraformFormat)
+}
+
+func TestGCPGolden(t *testing.T) {
+	runGoldenCases(t, []goldenCase{
+		{
+			name:     "gcp-postgres",
+			provider: func() Provider { return GetGCPProvider() },
+			answers:  []string{"latest", postgres, "16", "sandbox-project", "us-central1", "db-g1-small"},
+		},
+		{
+			name:     "gcp-mysql",
+			provider: func() Provider { return GetGCPProvider() },
+			answers:  []string{"7.58.0", mysql, "8.0", "sandbox-project", "europe-west1", "db-custom-1-3840"},
+		},
+		{
+			name:     "gcp-sqlserver",
+			provider: func() Provider { return GetGCPProvider() },
+			answers:  []string{"latest", sqlserver, "2022_EXPRESS", "sandbox-project", "us-central1", "db-custom-2-7680"},
+		},
+	}, checkTerraformFormat)
+}
+
+func TestCloudSQLDatabaseVersion(t *testing.T) {
+	tests := []struct {
+		dbms     string
+		version  string
+		expected string
+	}{
+		{postgres, "16", "POSTGRES_16"},
+		{mysql, "8.0", "MYSQL_8_0"},
+		{sqlserver, "2022_STANDARD", "SQLSERVER_2022_STANDARD"},
+	}
+
+	for _, test := range tests {
+		if got := cloudSQLDatabaseVersion(test.dbms, test.version); got != test.expected {
+			t.Errorf("cloudSQLDatabaseVersion(%q, %q) = %q, expected %q", test.dbms, test.version, got, test.expected)
+		}
+	}
+}
+
+func TestCloudResourceName(t *testing.T) {
+	tests := map[string]string{
+		"dbm-sandbox":           "dbm-sandbox",
+		"My  Sandbox":           "my-sandbox",
+		"2nd sandbox":           "dbm-2nd-sandbox",
+		"!!!":                   "dbm-sandbox",
+		strings.Repeat("a", 50): strings.Repeat("a", 40),
+	}
+
+	for name, expected := range tests {
+		if got := cloudResourceName(name); got != expected {
+			t.Errorf("cloudResourceName(%q) = %q, expected %q", name, got, expected)
+		}
+	}
+}
+
+// checkTerraformFormat reports the Terraform files that terraform fmt would
+// change: tabs, trailing whitespace, odd indentation, missing final newline
+// and attributes whose equal signs aren't aligned with their neighbours.
+func checkTerraformFormat(t *testing.T, file string, content []byte) {
+	t.Helper()
+
+	if ext := path.Ext(file); ext != ".tf" && ext != ".tfvars" {
+		return
+	}
+
+	text := string(content)
+	if !strings.HasSuffix(text, "\n") || strings.HasSuffix(text, "\n\n") {
+		t.Errorf("%s: must end with a single newline", file)
+	}
+
+	// group holds the consecutive attributes at the same indentation, which
+	// terraform fmt aligns.
+	type attribute struct {
+		line   int
+		column int
+		spaces int
+	}
+	group := []attribute{}
+	indentation := ""
+
+	checkGroup := func() {
+		if len(group) == 0 {
+			return
+		}
+
+		minSpaces := group[0].spaces
+		for _, a := range group {
+			if a.column != group[0].column {
+				t.Errorf("%s:%d: the equal sign is not aligned with line %d", file, a.line, group[0].line)
+			}
+			if a.spaces < minSpaces {
+				minSpaces = a.spaces
+			}
+		}
+
+		if minSpaces != 1 {
+			t.Errorf("%s:%d: the longest attribute must have a single space before the equal sign", file, group[0].line)
+		}
+
+		group = group[:0]
+	}
+
+	for ix, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+		number := ix + 1
+
+		if strings.Contains(line, "\t") {
+			t.Errorf("%s:%d: contains a tab", file, number)
+		}
+
+		if strings.TrimRight(line, " ") != line {
+			t.Errorf("%s:%d: has trailing whitespace", file, number)
+		}
+
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		if indent%2 != 0 {
+			t.Errorf("%s:%d: is not indented with a multiple of two spaces", file, number)
+		}
+
+		match := terraformAttribute.FindStringSubmatch(line)
+		opensBlock := strings.HasSuffix(line, "{") || strings.HasSuffix(line, "[") || strings.HasSuffix(line, "(")
+		if match == nil || opensBlock || match[1] != indentation {
+			checkGroup()
+		}
+
+		if match == nil || opensBlock {
+			indentation = ""
+			continue
+		}
+
+		indentation = match[1]
+		group = append(group, attribute{
+			line:   number,
+			column: len(match[1]) + len(match[2]) + len(match[3]),
+			spaces: len(match[3]),
+		})
+	}
+
+	checkGroup()
+}
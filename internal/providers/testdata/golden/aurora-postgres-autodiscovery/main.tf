@@ -0,0 +1,192 @@
+data "aws_vpc" "default" {
+  default = true
+}
+
+data "aws_ami" "amazon_linux" {
+  most_recent = true
+  owners      = ["amazon"]
+
+  filter {
+    name   = "name"
+    values = ["al2023-ami-2023.*-x86_64"]
+  }
+}
+
+resource "random_password" "db" {
+  length  = 24
+  special = false
+}
+
+resource "aws_security_group" "agent" {
+  name_prefix = "sandbox-agent-"
+  description = "Datadog Agent of the sandbox DBM sandbox"
+  vpc_id      = data.aws_vpc.default.id
+
+  egress {
+    from_port   = 0
+    to_port     = 0
+    protocol    = "-1"
+    cidr_blocks = ["0.0.0.0/0"]
+  }
+}
+
+resource "aws_security_group" "db" {
+  name_prefix = "sandbox-db-"
+  description = "Aurora Postgres of the sandbox DBM sandbox"
+  vpc_id      = data.aws_vpc.default.id
+
+  ingress {
+    description     = "Postgres from the Datadog Agent"
+    from_port       = 5432
+    to_port         = 5432
+    protocol        = "tcp"
+    security_groups = [aws_security_group.agent.id]
+  }
+}
+
+resource "aws_rds_cluster_parameter_group" "dbm" {
+  name_prefix = "sandbox-"
+  family      = "aurora-postgresql16"
+  description = "Database Monitoring settings for Aurora Postgres"
+
+  parameter {
+    name         = "shared_preload_libraries"
+    value        = "pg_stat_statements"
+    apply_method = "pending-reboot"
+  }
+
+  parameter {
+    name         = "track_activity_query_size"
+    value        = "4096"
+    apply_method = "pending-reboot"
+  }
+
+  parameter {
+    name         = "pg_stat_statements.max"
+    value        = "10000"
+    apply_method = "pending-reboot"
+  }
+
+  parameter {
+    name         = "pg_stat_statements.track"
+    value        = "ALL"
+    apply_method = "immediate"
+  }
+
+  parameter {
+    name         = "pg_stat_statements.track_utility"
+    value        = "0"
+    apply_method = "immediate"
+  }
+
+  parameter {
+    name         = "track_io_timing"
+    value        = "1"
+    apply_method = "immediate"
+  }
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+resource "aws_rds_cluster" "dbm" {
+  cluster_identifier              = "sandbox"
+  engine                          = "aurora-postgresql"
+  engine_version                  = "16.4"
+  master_username                 = var.db_username
+  master_password                 = random_password.db.result
+  port                            = 5432
+  db_cluster_parameter_group_name = aws_rds_cluster_parameter_group.dbm.name
+  vpc_security_group_ids          = [aws_security_group.db.id]
+  apply_immediately               = true
+  skip_final_snapshot             = true
+
+  tags = {
+    "datadoghq.com/scrape" = "true"
+  }
+}
+
+resource "aws_rds_cluster_instance" "writer" {
+  identifier          = "sandbox-writer"
+  cluster_identifier  = aws_rds_cluster.dbm.id
+  engine              = aws_rds_cluster.dbm.engine
+  engine_version      = aws_rds_cluster.dbm.engine_version
+  instance_class      = var.instance_class
+  publicly_accessible = false
+  apply_immediately   = true
+}
+
+resource "aws_rds_cluster_instance" "reader" {
+  count = 1
+
+  identifier          = "sandbox-reader-${count.index + 1}"
+  cluster_identifier  = aws_rds_cluster.dbm.id
+  engine              = aws_rds_cluster.dbm.engine
+  engine_version      = aws_rds_cluster.dbm.engine_version
+  instance_class      = var.instance_class
+  publicly_accessible = false
+  apply_immediately   = true
+
+  # The writer is created first so it isn't one of the readers.
+  depends_on = [aws_rds_cluster_instance.writer]
+}
+
+resource "aws_iam_role" "agent" {
+  name_prefix = "sandbox-agent-"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Effect    = "Allow"
+      Action    = "sts:AssumeRole"
+      Principal = { Service = "ec2.amazonaws.com" }
+    }]
+  })
+}
+
+resource "aws_iam_role_policy_attachment" "agent_ssm" {
+  role       = aws_iam_role.agent.name
+  policy_arn = "arn:aws:iam::aws:policy/AmazonSSMManagedInstanceCore"
+}
+
+resource "aws_iam_role_policy" "agent_autodiscovery" {
+  name_prefix = "sandbox-autodiscovery-"
+  role        = aws_iam_role.agent.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Effect   = "Allow"
+      Action   = ["rds:DescribeDBClusters", "rds:DescribeDBInstances"]
+      Resource = "*"
+    }]
+  })
+}
+
+resource "aws_iam_instance_profile" "agent" {
+  name_prefix = "sandbox-agent-"
+  role        = aws_iam_role.agent.name
+}
+
+resource "aws_instance" "agent" {
+  ami                         = data.aws_ami.amazon_linux.id
+  instance_type               = var.agent_instance_type
+  iam_instance_profile        = aws_iam_instance_profile.agent.name
+  vpc_security_group_ids      = [aws_security_group.agent.id]
+  user_data_replace_on_change = true
+
+  user_data = templatefile("${path.module}/user-data.sh.tftpl", {
+    datadog_api_key    = var.datadog_api_key
+    datadog_site       = var.datadog_site
+    db_host            = aws_rds_cluster.dbm.endpoint
+    db_username        = var.db_username
+    db_password        = random_password.db.result
+    bootstrap_sql      = file("${path.module}/bootstrap.sql")
+    instance_endpoints = concat([aws_rds_cluster_instance.writer.endpoint], aws_rds_cluster_instance.reader[*].endpoint)
+  })
+
+  tags = {
+    Name = "sandbox-agent"
+  }
+}
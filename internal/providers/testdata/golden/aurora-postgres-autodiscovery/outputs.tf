@@ -0,0 +1,25 @@
+output "cluster_endpoint" {
+  description = "The writer endpoint of the Aurora cluster"
+  value       = aws_rds_cluster.dbm.endpoint
+}
+
+output "reader_endpoint" {
+  description = "The reader endpoint of the Aurora cluster"
+  value       = aws_rds_cluster.dbm.reader_endpoint
+}
+
+output "instance_endpoints" {
+  description = "The endpoints of the writer and reader instances"
+  value       = concat([aws_rds_cluster_instance.writer.endpoint], aws_rds_cluster_instance.reader[*].endpoint)
+}
+
+output "db_password" {
+  description = "The password of the master user of the database"
+  value       = random_password.db.result
+  sensitive   = true
+}
+
+output "agent_instance_id" {
+  description = "The ID of the EC2 instance running the Datadog Agent"
+  value       = aws_instance.agent.id
+}
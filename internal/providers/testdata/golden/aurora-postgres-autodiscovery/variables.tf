@@ -0,0 +1,35 @@
+variable "region" {
+  description = "The AWS region the sandbox is created in"
+  type        = string
+  default     = "us-east-1"
+}
+
+variable "instance_class" {
+  description = "The instance class of the database"
+  type        = string
+  default     = "db.t3.medium"
+}
+
+variable "agent_instance_type" {
+  description = "The instance type of the EC2 instance running the Datadog Agent"
+  type        = string
+  default     = "t3.micro"
+}
+
+variable "db_username" {
+  description = "The name of the master user of the database"
+  type        = string
+  default     = "sandbox_admin"
+}
+
+variable "datadog_api_key" {
+  description = "The Datadog API Key used by the agent"
+  type        = string
+  sensitive   = true
+}
+
+variable "datadog_site" {
+  description = "The Datadog site the agent sends its data to"
+  type        = string
+  default     = "datadoghq.com"
+}
@@ -0,0 +1,7 @@
+#!/bin/sh
+# Creates the datadog user, runs in the bootstrap init container of the agent.
+until mysql -h "$DB_HOST" -u "$DB_USER" -p"$DB_PASSWORD" -e 'SELECT 1' > /dev/null; do
+  sleep 10
+done
+
+mysql --force -h "$DB_HOST" -u "$DB_USER" -p"$DB_PASSWORD" < /bootstrap/bootstrap.sql
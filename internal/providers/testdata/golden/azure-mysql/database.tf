@@ -0,0 +1,46 @@
+locals {
+  fqdn = azurerm_mysql_flexible_server.sandbox.fqdn
+
+  # Some of the parameters are static, the server has to be restarted once
+  # after they are set.
+  dbm_parameters = {
+    "performance_schema"                     = "ON"
+    "max_digest_length"                      = "4096"
+    "performance_schema_max_digest_length"   = "4096"
+    "performance_schema_max_sql_text_length" = "4096"
+    "require_secure_transport"               = "OFF"
+  }
+}
+
+resource "azurerm_mysql_flexible_server" "sandbox" {
+  name                   = local.server_name
+  resource_group_name    = azurerm_resource_group.sandbox.name
+  location               = azurerm_resource_group.sandbox.location
+  version                = "8.0.21"
+  sku_name               = "GP_Standard_D2ds_v4"
+  administrator_login    = var.admin_username
+  administrator_password = random_password.admin.result
+  tags                   = local.tags
+
+  lifecycle {
+    ignore_changes = [zone]
+  }
+}
+
+resource "azurerm_mysql_flexible_server_configuration" "dbm" {
+  for_each = local.dbm_parameters
+
+  name                = each.key
+  resource_group_name = azurerm_resource_group.sandbox.name
+  server_name         = azurerm_mysql_flexible_server.sandbox.name
+  value               = each.value
+}
+
+# Allows the connections from Azure services, like the agent container group.
+resource "azurerm_mysql_flexible_server_firewall_rule" "azure" {
+  name                = "allow-azure-services"
+  resource_group_name = azurerm_resource_group.sandbox.name
+  server_name         = azurerm_mysql_flexible_server.sandbox.name
+  start_ip_address    = "0.0.0.0"
+  end_ip_address      = "0.0.0.0"
+}
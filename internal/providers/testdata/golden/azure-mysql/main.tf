@@ -0,0 +1,91 @@
+resource "random_string" "suffix" {
+  length  = 6
+  special = false
+  upper   = false
+}
+
+resource "random_password" "admin" {
+  length      = 24
+  special     = false
+  min_lower   = 1
+  min_upper   = 1
+  min_numeric = 1
+}
+
+locals {
+  # Server names are globally unique.
+  server_name = "sandbox-${random_string.suffix.result}"
+
+  tags = {
+    project    = "sandbox"
+    created_by = "dbm-sandbox"
+  }
+}
+
+resource "azurerm_resource_group" "sandbox" {
+  name     = "sandbox-rg"
+  location = var.location
+  tags     = local.tags
+}
+
+resource "azurerm_container_group" "agent" {
+  name                = "sandbox-agent"
+  location            = azurerm_resource_group.sandbox.location
+  resource_group_name = azurerm_resource_group.sandbox.name
+  os_type             = "Linux"
+  ip_address_type     = "None"
+  restart_policy      = "Always"
+  tags                = local.tags
+
+  # Creates the datadog user before the agent starts, the script ignores the
+  # errors of objects that already exist when the group restarts.
+  init_container {
+    name     = "bootstrap"
+    image    = "mysql:8.0"
+    commands = ["sh", "/bootstrap/bootstrap.sh"]
+
+    environment_variables = {
+      DB_HOST = local.fqdn
+      DB_USER = var.admin_username
+    }
+
+    secure_environment_variables = {
+      DB_PASSWORD = random_password.admin.result
+    }
+
+    volume {
+      name       = "bootstrap"
+      mount_path = "/bootstrap"
+
+      secret = {
+        "bootstrap.sh"  = filebase64("${path.module}/bootstrap.sh")
+        "bootstrap.sql" = filebase64("${path.module}/bootstrap.sql")
+      }
+    }
+  }
+
+  container {
+    name   = "datadog-agent"
+    image  = "gcr.io/datadoghq/agent:7.58.0"
+    cpu    = 1
+    memory = 2
+
+    environment_variables = {
+      DD_SITE     = var.datadog_site
+      DD_HOSTNAME = "sandbox-agent"
+    }
+
+    secure_environment_variables = {
+      DD_API_KEY = var.datadog_api_key
+    }
+
+    volume {
+      name       = "check"
+      mount_path = "/etc/datadog-agent/conf.d/mysql.d"
+
+      secret = {
+        "conf.yaml" = base64encode(templatefile("${path.module}/conf.yaml.tftpl", { fqdn = local.fqdn }))
+      }
+    }
+  }
+}
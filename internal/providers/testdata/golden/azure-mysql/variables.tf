@@ -0,0 +1,23 @@
+variable "location" {
+  description = "The Azure location the sandbox is created in"
+  type        = string
+  default     = "westeurope"
+}
+
+variable "admin_username" {
+  description = "The name of the administrator of the database"
+  type        = string
+  default     = "sandboxadmin"
+}
+
+variable "datadog_api_key" {
+  description = "The Datadog API Key used by the agent"
+  type        = string
+  sensitive   = true
+}
+
+variable "datadog_site" {
+  description = "The Datadog site the agent sends its data to"
+  type        = string
+  default     = "datadoghq.com"
+}
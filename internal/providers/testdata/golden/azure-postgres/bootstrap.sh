@@ -0,0 +1,9 @@
+#!/bin/sh
+# Creates the datadog user, runs in the bootstrap init container of the agent.
+export PGPASSWORD="$DB_PASSWORD"
+
+until psql -h "$DB_HOST" -U "$DB_USER" -d postgres -c 'SELECT 1' > /dev/null; do
+  sleep 10
+done
+
+psql -h "$DB_HOST" -U "$DB_USER" -d postgres -f /bootstrap/bootstrap.sql
@@ -0,0 +1,46 @@
+locals {
+  fqdn = azurerm_postgresql_flexible_server.sandbox.fqdn
+
+  # Some of the parameters are static, the server has to be restarted once
+  # after they are set.
+  dbm_parameters = {
+    "azure.extensions"                 = "PG_STAT_STATEMENTS"
+    "pg_stat_statements.max"           = "10000"
+    "pg_stat_statements.track"         = "ALL"
+    "pg_stat_statements.track_utility" = "OFF"
+    "track_activity_query_size"        = "4096"
+    "track_io_timing"                  = "ON"
+  }
+}
+
+resource "azurerm_postgresql_flexible_server" "sandbox" {
+  name                   = local.server_name
+  resource_group_name    = azurerm_resource_group.sandbox.name
+  location               = azurerm_resource_group.sandbox.location
+  version                = "16"
+  sku_name               = "B_Standard_B2s"
+  storage_mb             = 32768
+  administrator_login    = var.admin_username
+  administrator_password = random_password.admin.result
+  tags                   = local.tags
+
+  lifecycle {
+    ignore_changes = [zone]
+  }
+}
+
+resource "azurerm_postgresql_flexible_server_configuration" "dbm" {
+  for_each = local.dbm_parameters
+
+  name      = each.key
+  server_id = azurerm_postgresql_flexible_server.sandbox.id
+  value     = each.value
+}
+
+# Allows the connections from Azure services, like the agent container group.
+resource "azurerm_postgresql_flexible_server_firewall_rule" "azure" {
+  name             = "allow-azure-services"
+  server_id        = azurerm_postgresql_flexible_server.sandbox.id
+  start_ip_address = "0.0.0.0"
+  end_ip_address   = "0.0.0.0"
+}
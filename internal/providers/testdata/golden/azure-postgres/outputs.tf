@@ -0,0 +1,15 @@
+output "fqdn" {
+  description = "The fully qualified domain name of the Postgres server"
+  value       = local.fqdn
+}
+
+output "admin_password" {
+  description = "The password of the administrator of the database"
+  value       = random_password.admin.result
+  sensitive   = true
+}
+
+output "resource_group" {
+  description = "The resource group of the sandbox"
+  value       = azurerm_resource_group.sandbox.name
+}
@@ -0,0 +1,5 @@
+-- Create the Datadog Login
+CREATE LOGIN datadog WITH PASSWORD = 'Datadog-Sandbox-1';
+CREATE USER datadog FOR LOGIN datadog;
+ALTER SERVER ROLE ##MS_ServerStateReader## ADD MEMBER datadog;
+ALTER SERVER ROLE ##MS_DefinitionReader## ADD MEMBER datadog;
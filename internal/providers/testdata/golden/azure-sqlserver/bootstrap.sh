@@ -0,0 +1,13 @@
+#!/bin/sh
+# Creates the datadog login and user, runs in the bootstrap init container of
+# the agent. Azure SQL doesn't support USE, so each database has its script.
+sqlcmd() {
+  /opt/mssql-tools/bin/sqlcmd -S "$DB_HOST" -U "$DB_USER" -P "$DB_PASSWORD" "$@"
+}
+
+until sqlcmd -d master -Q 'SELECT 1' > /dev/null; do
+  sleep 10
+done
+
+sqlcmd -d master -i /bootstrap/bootstrap-master.sql
+sqlcmd -d sandbox -i /bootstrap/bootstrap.sql
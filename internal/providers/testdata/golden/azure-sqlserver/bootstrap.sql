@@ -0,0 +1,2 @@
+-- Create the Datadog User in the sandbox database
+CREATE USER datadog FOR LOGIN datadog;
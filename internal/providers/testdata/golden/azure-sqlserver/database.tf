@@ -0,0 +1,28 @@
+locals {
+  fqdn = azurerm_mssql_server.sandbox.fully_qualified_domain_name
+}
+
+resource "azurerm_mssql_server" "sandbox" {
+  name                         = local.server_name
+  resource_group_name          = azurerm_resource_group.sandbox.name
+  location                     = azurerm_resource_group.sandbox.location
+  version                      = "12.0"
+  administrator_login          = var.admin_username
+  administrator_login_password = random_password.admin.result
+  tags                         = local.tags
+}
+
+resource "azurerm_mssql_database" "sandbox" {
+  name      = "sandbox"
+  server_id = azurerm_mssql_server.sandbox.id
+  sku_name  = "S0"
+  tags      = local.tags
+}
+
+# Allows the connections from Azure services, like the agent container group.
+resource "azurerm_mssql_firewall_rule" "azure" {
+  name             = "allow-azure-services"
+  server_id        = azurerm_mssql_server.sandbox.id
+  start_ip_address = "0.0.0.0"
+  end_ip_address   = "0.0.0.0"
+}
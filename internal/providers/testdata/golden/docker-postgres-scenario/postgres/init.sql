@@ -0,0 +1,10 @@
+-- Datadog Configuration
+CREATE USER datadog WITH PASSWORD 'root';
+ALTER ROLE datadog INHERIT;
+CREATE SCHEMA datadog;
+GRANT USAGE ON SCHEMA datadog TO datadog;
+GRANT USAGE ON SCHEMA public TO datadog;
+GRANT pg_monitor TO datadog;
+CREATE EXTENSION IF NOT EXISTS pg_stat_statements;
+
+
@@ -0,0 +1,102 @@
+data "google_compute_zones" "available" {
+  region = var.region
+}
+
+resource "random_string" "suffix" {
+  length  = 6
+  special = false
+  upper   = false
+}
+
+resource "random_password" "db" {
+  length  = 24
+  special = false
+}
+
+# The address of the agent VM, the only network allowed to connect to the
+# Cloud SQL instance.
+resource "google_compute_address" "agent" {
+  name   = "sandbox-agent"
+  region = var.region
+}
+
+resource "google_sql_database_instance" "dbm" {
+  # Instance names can't be reused for a week after the instance is deleted.
+  name                = "sandbox-${random_string.suffix.result}"
+  database_version    = "MYSQL_8_0"
+  region              = var.region
+  deletion_protection = false
+
+  settings {
+    tier    = var.tier
+    edition = "ENTERPRISE"
+
+    database_flags {
+      name  = "performance_schema"
+      value = "on"
+    }
+
+    database_flags {
+      name  = "max_digest_length"
+      value = "4096"
+    }
+
+    database_flags {
+      name  = "performance_schema_max_digest_length"
+      value = "4096"
+    }
+
+    database_flags {
+      name  = "performance_schema_max_sql_text_length"
+      value = "4096"
+    }
+
+    ip_configuration {
+      ipv4_enabled = true
+
+      authorized_networks {
+        name  = "datadog-agent"
+        value = google_compute_address.agent.address
+      }
+    }
+  }
+}
+
+resource "google_sql_user" "admin" {
+  name     = var.db_username
+  instance = google_sql_database_instance.dbm.name
+  password = random_password.db.result
+}
+
+resource "google_compute_instance" "agent" {
+  name         = "sandbox-agent"
+  machine_type = var.agent_machine_type
+  zone         = data.google_compute_zones.available.names[0]
+
+  boot_disk {
+    initialize_params {
+      image = "debian-cloud/debian-12"
+    }
+  }
+
+  network_interface {
+    network = "default"
+
+    access_config {
+      nat_ip = google_compute_address.agent.address
+    }
+  }
+
+  metadata_startup_script = templatefile("${path.module}/startup-script.sh.tftpl", {
+    datadog_api_key = var.datadog_api_key
+    datadog_site    = var.datadog_site
+    project_id      = var.project_id
+    instance_id     = google_sql_database_instance.dbm.name
+    db_host         = google_sql_database_instance.dbm.public_ip_address
+    db_username     = var.db_username
+    db_password     = random_password.db.result
+    bootstrap_sql   = file("${path.module}/bootstrap.sql")
+  })
+
+  depends_on = [google_sql_user.admin]
+}
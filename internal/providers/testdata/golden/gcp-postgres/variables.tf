@@ -0,0 +1,41 @@
+variable "project_id" {
+  description = "The ID of the GCP project the sandbox is created in"
+  type        = string
+  default     = "sandbox-project"
+}
+
+variable "region" {
+  description = "The GCP region the sandbox is created in"
+  type        = string
+  default     = "us-central1"
+}
+
+variable "tier" {
+  description = "The machine tier of the Cloud SQL instance"
+  type        = string
+  default     = "db-g1-small"
+}
+
+variable "agent_machine_type" {
+  description = "The machine type of the VM running the Datadog Agent"
+  type        = string
+  default     = "e2-medium"
+}
+
+variable "db_username" {
+  description = "The name of the administrator of the database"
+  type        = string
+  default     = "sandbox_admin"
+}
+
+variable "datadog_api_key" {
+  description = "The Datadog API Key used by the agent"
+  type        = string
+  sensitive   = true
+}
+
+variable "datadog_site" {
+  description = "The Datadog site the agent sends its data to"
+  type        = string
+  default     = "datadoghq.com"
+}
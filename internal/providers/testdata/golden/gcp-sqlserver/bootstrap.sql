@@ -0,0 +1,5 @@
+-- Create the Datadog Login
+CREATE LOGIN datadog WITH PASSWORD = 'Datadog-Sandbox-1';
+CREATE USER datadog FOR LOGIN datadog;
+GRANT VIEW SERVER STATE TO datadog;
+GRANT VIEW ANY DEFINITION TO datadog;
@@ -0,0 +1,25 @@
+output "instance_id" {
+  description = "The ID of the Cloud SQL instance"
+  value       = google_sql_database_instance.dbm.name
+}
+
+output "db_host" {
+  description = "The public IP address of the Cloud SQL instance"
+  value       = google_sql_database_instance.dbm.public_ip_address
+}
+
+output "db_password" {
+  description = "The password of the administrator of the database"
+  value       = random_password.db.result
+  sensitive   = true
+}
+
+output "agent_instance" {
+  description = "The name of the VM running the Datadog Agent"
+  value       = google_compute_instance.agent.name
+}
+
+output "agent_zone" {
+  description = "The zone of the VM running the Datadog Agent"
+  value       = google_compute_instance.agent.zone
+}
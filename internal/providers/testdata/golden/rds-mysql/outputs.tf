@@ -0,0 +1,15 @@
+output "db_endpoint" {
+  description = "The endpoint of the MySQL instance"
+  value       = aws_db_instance.dbm.address
+}
+
+output "db_password" {
+  description = "The password of the master user of the database"
+  value       = random_password.db.result
+  sensitive   = true
+}
+
+output "agent_instance_id" {
+  description = "The ID of the EC2 instance running the Datadog Agent"
+  value       = aws_instance.agent.id
+}
@@ -0,0 +1,10 @@
+#!/bin/bash
+# Installs the 7.58.0 version of the Datadog Agent from the OS
+# packages and configures the mysql check.
+set -euo pipefail
+
+DD_AGENT_MAJOR_VERSION=7 DD_AGENT_MINOR_VERSION='58.0' DD_HOSTNAME='sandbox' \
+  bash -c "$(curl -L https://install.datadoghq.com/scripts/install_script_agent7.sh)"
+
+install -o dd-agent -g dd-agent -m 0640 /tmp/dbm-sandbox/conf.d/mysql.d/conf.yaml /etc/datadog-agent/conf.d/mysql.d/conf.yaml
+systemctl restart datadog-agent
@@ -0,0 +1,27 @@
+#!/bin/bash
+# Installs MySQL 8.0.37 from the packages of the MySQL archives and
+# applies the Datadog settings of datadog.cnf.
+set -euo pipefail
+
+export DEBIAN_FRONTEND=noninteractive
+
+apt-get update
+apt-get install -y curl
+
+bundle="mysql-server_8.0.37-1ubuntu22.04_amd64.deb-bundle.tar"
+mkdir -p /tmp/dbm-sandbox/packages
+curl -fsSL -o "/tmp/dbm-sandbox/$bundle" "https://downloads.mysql.com/archives/get/p/23/file/$bundle"
+tar -xf "/tmp/dbm-sandbox/$bundle" -C /tmp/dbm-sandbox/packages
+
+# Without a root password the packages set up root to log in with its socket.
+cd /tmp/dbm-sandbox/packages
+apt-get install -y ./mysql-common_*.deb ./mysql-community-client-plugins_*.deb \
+  ./mysql-community-client-core_*.deb ./mysql-community-client_*.deb ./mysql-client_*.deb \
+  ./mysql-community-server-core_*.deb ./mysql-community-server_*.deb ./mysql-server_*.deb
+
+install -m 0644 /tmp/dbm-sandbox/mysql/conf.d/datadog.cnf /etc/mysql/conf.d/datadog.cnf
+systemctl restart mysql
+
+# The errors of the objects that already exist are ignored when provisioning
+# again.
+mysql --force < /tmp/dbm-sandbox/mysql/init-sql/datadog-conf.sql
@@ -0,0 +1,10 @@
+#!/bin/bash
+# Installs the latest version of the Datadog Agent from the OS
+# packages and configures the postgres check.
+set -euo pipefail
+
+DD_AGENT_MAJOR_VERSION=7 DD_HOSTNAME='sandbox' \
+  bash -c "$(curl -L https://install.datadoghq.com/scripts/install_script_agent7.sh)"
+
+install -o dd-agent -g dd-agent -m 0640 /tmp/dbm-sandbox/conf.d/postgres.d/conf.yaml /etc/datadog-agent/conf.d/postgres.d/conf.yaml
+systemctl restart datadog-agent
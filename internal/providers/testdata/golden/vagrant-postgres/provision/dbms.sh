@@ -0,0 +1,20 @@
+#!/bin/bash
+# Installs Postgres 16 from the packages of the PostgreSQL Global
+# Development Group and applies the Datadog settings of postgresql.conf.
+set -euo pipefail
+
+export DEBIAN_FRONTEND=noninteractive
+
+apt-get update
+apt-get install -y postgresql-common
+/usr/share/postgresql-common/pgdg/apt.postgresql.org.sh -y
+apt-get install -y postgresql-16
+
+# The packaged postgresql.conf includes the files of its conf.d directory,
+# which take precedence over its own settings.
+install -o postgres -g postgres -m 0644 /tmp/dbm-sandbox/postgres/postgresql.conf /etc/postgresql/16/main/conf.d/datadog.conf
+systemctl restart postgresql@16-main
+
+# The errors of the objects that already exist are ignored when provisioning
+# again.
+sudo -u postgres psql -f /tmp/dbm-sandbox/postgres/init.sql
@@ -0,0 +1,149 @@
+package providers
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/aldrickdev/dbm-sandbox/internal/utils/helpers"
+)
+
+const (
+	VAGRANT = "Vagrant"
+
+	// vagrantDBHost is the host the agent reaches the DBMS at, both run on the
+	// same VM.
+	vagrantDBHost = "127.0.0.1"
+)
+
+// VagrantProvider implements the Provider Interface and generates a
+// Vagrantfile with provisioning scripts that install the DBMS and the Datadog
+// Agent from their OS packages, to reproduce issues that only happen outside
+// of containers.
+type VagrantProvider struct {
+	eventEmitter
+	questionnaire
+
+	// supportedDBMS is a slice of names for all of the DBMS's that this provider
+	// supports.
+	supportedDBMS []string
+
+	// templatePath is the location in the templateFS where the templates for
+	// this provider are located.
+	templatePath string
+	// dbmsTemplatePath is the location in the templateFS of the DBMS
+	// configuration files, shared with the DockerProvider.
+	dbmsTemplatePath string
+	// templateData is the data required to fill the project template files for
+	// this provider.
+	templateData vagrantTemplateData
+	// templateFS is where the template files for this provider are located.
+	templateFS fs.FS
+}
+
+// vagrantTemplateData is used to contain the data for the VagrantProvider
+// template files.
+type vagrantTemplateData struct {
+	Agent agentTemplateData
+	DB    dbTemplateData
+
+	// Hostname is the hostname of the VM, also used as the name of the agent
+	// host in Datadog.
+	Hostname string
+	// DBMSDirectory is the directory of the project the DBMS configuration
+	// files are copied to.
+	DBMSDirectory string
+	// Check is the name of the agent integration check for the DBMS.
+	Check string
+	// AgentMinorVersion is the minor version of the agent to install, empty to
+	// install the latest version.
+	AgentMinorVersion string
+	// AgentConf is the agent integration configuration for the DBMS.
+	AgentConf string
+}
+
+// GetVagrantProvider will initiallize a new VagrantProvider instance and
+// return a pointer to it.
+func GetVagrantProvider() *VagrantProvider {
+	vp := &VagrantProvider{
+		supportedDBMS:    []string{postgres, mysql},
+		templatePath:     "embed/vagrant/",
+		dbmsTemplatePath: "embed/docker/",
+		templateFS:       templateFS,
+	}
+	vp.generateProviderQuestions()
+
+	return vp
+}
+
+// generateProviderQuestions generates all of the questions that are required
+// to fill the providers template data.
+func (v *VagrantProvider) generateProviderQuestions() {
+	v.addCommonQuestions(v.supportedDBMS)
+}
+
+// fillTemplateData will fill the VagrantProvider.templateData with the
+// answers and the agent configuration.
+func (v *VagrantProvider) fillTemplateData(ddapikey string) error {
+	dbmsName := v.answer(DBMSIndex)
+
+	agentConf, err := readDBMSFile(v.templateFS, v.dbmsTemplatePath, dbmsName, "conf.d/"+agentConfDirectory(dbmsName)+"/conf.yaml")
+	if err != nil {
+		return err
+	}
+
+	v.templateData = vagrantTemplateData{
+		Agent: agentTemplateData{
+			Version:     v.answer(AgentVersionIndex),
+			DDAPIKey:    ddapikey,
+			ProjectName: v.answer(ProjectNameIndex),
+		},
+		DB: dbTemplateData{
+			DBMS:    dbmsName,
+			Version: v.answer(DBMSVersionIndex),
+		},
+		Hostname:          cloudResourceName(v.answer(ProjectNameIndex)),
+		DBMSDirectory:     dbServiceName(dbmsName),
+		Check:             agentCheckName(dbmsName),
+		AgentMinorVersion: agentMinorVersion(v.answer(AgentVersionIndex)),
+		AgentConf:         replaceAgentHost(agentConf, dbmsName, vagrantDBHost),
+	}
+
+	return nil
+}
+
+// projectFiles returns the files that are rendered on top of the DBMS
+// configuration files, the agent configuration replaces the one of the
+// container sandboxes.
+func (v *VagrantProvider) projectFiles() []projectFile {
+	return []projectFile{
+		{template: v.templatePath + "README.md.tmpl", destination: "README.md"},
+		{template: v.templatePath + "Vagrantfile.tmpl", destination: "Vagrantfile"},
+		{template: v.templatePath + v.templateData.Check + ".sh.tmpl", destination: "provision/dbms.sh"},
+		{template: v.templatePath + "agent.sh.tmpl", destination: "provision/agent.sh"},
+		{template: v.templatePath + "conf.yaml.tmpl", destination: "conf.d/" + agentConfDirectory(v.templateData.DB.DBMS) + "/conf.yaml"},
+	}
+}
+
+// GenerateProject will generate the project directory on the users machine
+// with the Vagrantfile, the provisioning scripts and the DBMS configuration
+// files.
+func (v *VagrantProvider) GenerateProject(ddapikey string) error {
+	if err := v.fillTemplateData(ddapikey); err != nil {
+		return err
+	}
+
+	projectName := v.templateData.Agent.ProjectName
+	if err := v.createProjectDirectory(projectName); err != nil {
+		return err
+	}
+
+	dbms := v.dbmsTemplatePath + strings.ToLower(v.templateData.DB.DBMS)
+	if err := v.runStep(fmt.Sprintf("Copy the %s configuration files", v.templateData.DB.DBMS), func() error {
+		return helpers.CopyDirectoryFS(v.templateFS, dbms, projectName)
+	}); err != nil {
+		return err
+	}
+
+	return v.renderProjectFiles(v.templateFS, projectName, v.projectFiles(), v.templateData)
+}
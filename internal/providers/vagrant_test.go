@@ -0,0 +1,40 @@
+package providers
+
+import (
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestVagrantGolden(t *testing.T) {
+	runGoldenCases(t, []goldenCase{
+		{
+			name:     "vagrant-postgres",
+			provider: func() Provider { return GetVagrantProvider() },
+			answers:  []string{"latest", postgres, "16"},
+		},
+		{
+			name:     "vagrant-mysql",
+			provider: func() Provider { return GetVagrantProvider() },
+			answers:  []string{"7.58.0", mysql, "8.0.37"},
+		},
+	}, checkProvisionScript)
+}
+
+// checkProvisionScript reports the provisioning scripts that don't stop on
+// the first failing command.
+func checkProvisionScript(t *testing.T, file string, content []byte) {
+	t.Helper()
+
+	if path.Ext(file) != ".sh" {
+		return
+	}
+
+	if !strings.HasPrefix(string(content), "#!/bin/bash\n") {
+		t.Errorf("%s: must start with a bash shebang", file)
+	}
+
+	if !strings.Contains(string(content), "\nset -euo pipefail\n") {
+		t.Errorf("%s: must set -euo pipefail", file)
+	}
+}
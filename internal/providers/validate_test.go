@@ -0,0 +1,32 @@
+package providers
+
+import (
+	"testing"
+
+	"github.com/aldrickdev/dbm-sandbox/internal/validate"
+)
+
+func TestDockerProjectsAreValid(t *testing.T) {
+	tests := []struct {
+		DBMSName string
+		answers  []string
+	}{
+		{DBMSName: postgres, answers: []string{"7.60.0", postgres, "16", noScenario, no}},
+		{DBMSName: mysql, answers: []string{"7.60.0", mysql, "8.0.37", noScenario, yes}},
+		{DBMSName: sqlserver, answers: []string{"7.60.0", sqlserver, "2022-latest", no}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.DBMSName, func(t *testing.T) {
+			generateGoldenProject(t, goldenCase{
+				name:     test.DBMSName,
+				provider: func() Provider { return GetDockerProvider() },
+				answers:  test.answers,
+			})
+
+			if problems := validate.Project(goldenProjectName); len(problems) != 0 {
+				t.Errorf("the generated project is invalid: %v", problems)
+			}
+		})
+	}
+}
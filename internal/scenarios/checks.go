@@ -0,0 +1,240 @@
+package scenarios
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/aldrickdev/dbm-sandbox/internal/validate"
+)
+
+var (
+	// pgMonitorGrant matches the grant of the pg_monitor role to the datadog
+	// user.
+	pgMonitorGrant = regexp.MustCompile(`(?i)\bGRANT\s+pg_monitor\s+TO\s+"?datadog"?\s*;`)
+	// pgExplainFunction matches the creation of the explain_statement
+	// function in the datadog schema.
+	pgExplainFunction = regexp.MustCompile(`(?i)\bCREATE\s+(?:OR\s+REPLACE\s+)?FUNCTION\s+"?datadog"?\."?explain_statement"?\s*\(`)
+
+	// mysqlConsumers are the performance_schema consumers the agent needs to
+	// collect query samples.
+	mysqlConsumers = []string{
+		"events_statements_current",
+		"events_statements_history",
+		"events_statements_history_long",
+		"events_waits_current",
+	}
+)
+
+// A Check verifies one part of the configuration of a sandbox.
+type Check struct {
+	// Name describes what is verified.
+	Name string
+	// DBMS is the DBMS of the sandboxes the check applies to.
+	DBMS string
+
+	// run returns an error describing what is wrong with the project found
+	// in the directory.
+	run func(dir string) error
+}
+
+// A Result is the outcome of a Check, Err is nil when it passed.
+type Result struct {
+	Check string
+	Err   error
+}
+
+// checks holds every check, in the order they are run.
+var checks = []Check{
+	{Name: "pg_stat_statements is loaded at startup", DBMS: postgres, run: checkPostgresPreload},
+	{Name: "The datadog user has the pg_monitor role", DBMS: postgres, run: checkPostgresGrant},
+	{Name: "The datadog.explain_statement function is created", DBMS: postgres, run: checkPostgresExplain},
+	{Name: "The agent uses the password of the datadog user", DBMS: postgres, run: checkPostgresPassword},
+	{Name: "performance_schema is enabled", DBMS: mysql, run: checkMySQLPerformanceSchema},
+	{Name: "The performance_schema consumers are enabled", DBMS: mysql, run: checkMySQLConsumers},
+	{Name: "The agent uses the password of the datadog user", DBMS: mysql, run: checkMySQLPassword},
+}
+
+// RunChecks runs every check of the DBMS against the project found in the
+// directory.
+func RunChecks(dir string, DBMSName string) []Result {
+	results := []Result{}
+	for _, check := range checks {
+		if check.DBMS == DBMSName {
+			results = append(results, Result{Check: check.Name, Err: check.run(dir)})
+		}
+	}
+
+	return results
+}
+
+// DetectDBMS returns the DBMS of the Docker sandbox found in the directory,
+// using the agent configuration it contains.
+func DetectDBMS(dir string) (string, error) {
+	for DBMSName, conf := range map[string]string{postgres: postgresAgentConf, mysql: mysqlAgentConf} {
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(conf))); err == nil {
+			return DBMSName, nil
+		}
+	}
+
+	return "", fmt.Errorf("No Postgres or MySQL sandbox found in %q", dir)
+}
+
+func checkPostgresPreload(dir string) error {
+	content, err := readProjectFile(dir, postgresConf)
+	if err != nil {
+		return err
+	}
+
+	value, ok := confSetting(content, "shared_preload_libraries", "#")
+	if !ok {
+		return fmt.Errorf("shared_preload_libraries isn't set in %s", postgresConf)
+	}
+
+	for _, library := range strings.Split(strings.Trim(value, `'"`), ",") {
+		if strings.TrimSpace(library) == "pg_stat_statements" {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("shared_preload_libraries doesn't include pg_stat_statements in %s", postgresConf)
+}
+
+func checkPostgresGrant(dir string) error {
+	content, err := readProjectFile(dir, postgresInitSQL)
+	if err != nil {
+		return err
+	}
+
+	if !pgMonitorGrant.MatchString(validate.StripSQLComments(content)) {
+		return fmt.Errorf("pg_monitor isn't granted to datadog in %s", postgresInitSQL)
+	}
+
+	return nil
+}
+
+func checkPostgresExplain(dir string) error {
+	content, err := readProjectFile(dir, postgresInitSQL)
+	if err != nil {
+		return err
+	}
+
+	if !pgExplainFunction.MatchString(validate.StripSQLComments(content)) {
+		return fmt.Errorf("datadog.explain_statement isn't created in %s", postgresInitSQL)
+	}
+
+	return nil
+}
+
+func checkPostgresPassword(dir string) error {
+	return credentialsError(validate.Credentials(dir, "postgres.d"))
+}
+
+func checkMySQLPerformanceSchema(dir string) error {
+	content, err := readProjectFile(dir, mysqlConf)
+	if err != nil {
+		return err
+	}
+
+	value, ok := confSetting(normalizeMySQLOptions(content), "performance_schema", "#;")
+	if !ok || !enabled(value) {
+		return fmt.Errorf("performance_schema isn't ON in %s", mysqlConf)
+	}
+
+	return nil
+}
+
+func checkMySQLConsumers(dir string) error {
+	content, err := readProjectFile(dir, mysqlConf)
+	if err != nil {
+		return err
+	}
+	content = normalizeMySQLOptions(content)
+
+	disabled := []string{}
+	for _, consumer := range mysqlConsumers {
+		value, ok := confSetting(content, "performance_schema_consumer_"+consumer, "#;")
+		if !ok || !enabled(value) {
+			disabled = append(disabled, consumer)
+		}
+	}
+
+	if len(disabled) > 0 {
+		return fmt.Errorf("the %s consumers aren't ON in %s", strings.Join(disabled, ", "), mysqlConf)
+	}
+
+	return nil
+}
+
+func checkMySQLPassword(dir string) error {
+	return credentialsError(validate.Credentials(dir, "mysql.d"))
+}
+
+// credentialsError returns the first problem with the credentials of the
+// agent, nil when there is none.
+func credentialsError(problems []validate.Problem) error {
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%s", problems[0])
+}
+
+// readProjectFile returns the content of the file of the project.
+func readProjectFile(dir string, name string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		return "", fmt.Errorf("Failed to read %s, error: %q", name, err)
+	}
+
+	return string(content), nil
+}
+
+// confSetting returns the value of the last uncommented setting with the
+// name, in a file of name = value lines.
+func confSetting(content string, name string, comments string) (value string, ok bool) {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.ContainsAny(line[:1], comments) {
+			continue
+		}
+
+		key, setting, found := strings.Cut(line, "=")
+		if found && strings.TrimSpace(key) == name {
+			// Remove the comment found after the value
+			if ix := strings.IndexAny(setting, comments); ix != -1 {
+				setting = setting[:ix]
+			}
+			value, ok = strings.TrimSpace(setting), true
+		}
+	}
+
+	return value, ok
+}
+
+// normalizeMySQLOptions replaces the dashes of the option names with
+// underscores, MySQL accepts both.
+func normalizeMySQLOptions(content string) string {
+	lines := strings.Split(content, "\n")
+	for ix, line := range lines {
+		key, value, found := strings.Cut(line, "=")
+		if found {
+			lines[ix] = strings.ReplaceAll(key, "-", "_") + "=" + value
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// enabled reports whether the MySQL boolean option value is on.
+func enabled(value string) bool {
+	switch strings.ToUpper(strings.Trim(value, `'"`)) {
+	case "ON", "1", "TRUE", "YES":
+		return true
+
+	default:
+		return false
+	}
+}
@@ -0,0 +1,113 @@
+package scenarios
+
+// The files of the Docker sandboxes the scenarios edit, relative to the
+// project directory.
+const (
+	postgresConf      = "postgres/postgresql.conf"
+	postgresInitSQL   = "postgres/init.sql"
+	postgresAgentConf = "conf.d/postgres.d/conf.yaml"
+	mysqlConf         = "mysql/conf.d/datadog.cnf"
+	mysqlAgentConf    = "conf.d/mysql.d/conf.yaml"
+)
+
+// postgresExplainFunction is the function the agent uses to collect explain
+// plans, as found in the init SQL.
+const postgresExplainFunction = `-- Explain Plans Function
+CREATE OR REPLACE FUNCTION datadog.explain_statement(
+   l_query TEXT,
+   OUT explain JSON
+)
+RETURNS SETOF JSON AS
+$$
+DECLARE
+curs REFCURSOR;
+plan JSON;
+
+BEGIN
+   OPEN curs FOR EXECUTE pg_catalog.concat('EXPLAIN (FORMAT JSON) ', l_query);
+   FETCH curs INTO plan;
+   CLOSE curs;
+   RETURN QUERY SELECT plan;
+END;
+$$
+LANGUAGE 'plpgsql'
+RETURNS NULL ON NULL INPUT
+SECURITY DEFINER;
+`
+
+// recreate is the command that applies the fixed configuration, the init SQL
+// only runs when the database container is created.
+const recreate = "Then recreate the containers with `" + composePlaceholder + " up -d --force-recreate`."
+
+// library holds every scenario, in the order they are offered.
+var library = []Scenario{
+	{
+		Name:    "pg-no-query-metrics",
+		Symptom: "Query metrics are missing and the agent status reports an error about pg_stat_statements",
+		DBMS:    postgres,
+		answerKey: "pg_stat_statements was removed from shared_preload_libraries in postgres/postgresql.conf. " +
+			"The extension can be created but its view can't be queried until the library is loaded when Postgres starts. " +
+			"Set shared_preload_libraries = 'pg_stat_statements'. " + recreate,
+		edits: []edit{
+			{file: postgresConf, old: "shared_preload_libraries = 'pg_stat_statements'", new: "shared_preload_libraries = ''"},
+		},
+	},
+	{
+		Name:    "pg-no-explain-plans",
+		Symptom: "Query samples are collected but none of them have an explain plan",
+		DBMS:    postgres,
+		answerKey: "The datadog.explain_statement function was removed from postgres/init.sql. " +
+			"The agent calls it to collect explain plans since the datadog user can't run EXPLAIN on queries it can't execute. " +
+			"Create the function in the datadog schema with SECURITY DEFINER as documented in the Postgres setup guide. " + recreate,
+		edits: []edit{
+			{file: postgresInitSQL, old: postgresExplainFunction, new: ""},
+		},
+	},
+	{
+		Name:    "pg-permission-denied",
+		Symptom: "Query samples only show <insufficient privilege> and activity is missing for other users",
+		DBMS:    postgres,
+		answerKey: "GRANT pg_monitor TO datadog; was removed from postgres/init.sql. " +
+			"Without the pg_monitor role the datadog user can only see its own sessions in pg_stat_activity and pg_stat_statements. " +
+			"Grant the role back. " + recreate,
+		edits: []edit{
+			{file: postgresInitSQL, old: "GRANT pg_monitor TO datadog;\n", new: ""},
+		},
+	},
+	{
+		Name:    "pg-agent-cannot-connect",
+		Symptom: "The postgres check fails and no database metrics are reported",
+		DBMS:    postgres,
+		answerKey: "The password in conf.d/postgres.d/conf.yaml doesn't match the password of the datadog user created in postgres/init.sql. " +
+			"The agent logs show password authentication failed for user \"datadog\". " +
+			"Use the same password in both files, then restart the agent with `" + composePlaceholder + " restart datadog-agent`.",
+		edits: []edit{
+			{file: postgresAgentConf, old: "password: root", new: "password: r00t"},
+		},
+	},
+	{
+		Name:    "mysql-no-query-samples",
+		Symptom: "Query metrics are reported but query samples and explain plans are missing",
+		DBMS:    mysql,
+		answerKey: "The performance_schema statement and wait consumers were turned off in mysql/conf.d/datadog.cnf. " +
+			"The agent reads query samples from the events_statements_* tables, which stay empty while their consumers are disabled. " +
+			"Turn events-statements-current, events-statements-history, events-statements-history-long and events-waits-current back ON. " + recreate,
+		edits: []edit{
+			{file: mysqlConf, old: "performance-schema-consumer-events-statements-current=ON", new: "performance-schema-consumer-events-statements-current=OFF"},
+			{file: mysqlConf, old: "performance-schema-consumer-events-waits-current=ON", new: "performance-schema-consumer-events-waits-current=OFF"},
+			{file: mysqlConf, old: "performance-schema-consumer-events-statements-history-long=ON", new: "performance-schema-consumer-events-statements-history-long=OFF"},
+			{file: mysqlConf, old: "performance-schema-consumer-events-statements-history=ON", new: "performance-schema-consumer-events-statements-history=OFF"},
+		},
+	},
+	{
+		Name:    "mysql-agent-cannot-connect",
+		Symptom: "The mysql check fails and no database metrics are reported",
+		DBMS:    mysql,
+		answerKey: "The password in conf.d/mysql.d/conf.yaml doesn't match the password of the datadog user created in mysql/init-sql/datadog-conf.sql. " +
+			"The agent logs show Access denied for user 'datadog'. " +
+			"Use the same password in both files, then restart the agent with `" + composePlaceholder + " restart datadog-agent`.",
+		edits: []edit{
+			{file: mysqlAgentConf, old: "password: datadog123", new: "password: datadog321"},
+		},
+	},
+}
@@ -0,0 +1,162 @@
+package scenarios
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	postgres = "Postgres"
+	mysql    = "MySQL"
+
+	// MarkerName is the file, in the project directory, that records the
+	// scenario applied to the sandbox.
+	MarkerName = ".dbm-sandbox-scenario"
+
+	// composePlaceholder is replaced by the compose command of the sandbox in
+	// the answer keys.
+	composePlaceholder = "{{compose}}"
+)
+
+// A Scenario breaks the configuration of a generated sandbox in a controlled
+// way, for troubleshooting practice. Only the symptom is shown to the user,
+// the answer key explains what was broken and how to fix it.
+type Scenario struct {
+	// Name identifies the scenario, it describes the symptom rather than the
+	// cause so that it doesn't give the answer away.
+	Name string
+	// Symptom is what the user observes in Datadog.
+	Symptom string
+	// DBMS is the DBMS the scenario applies to.
+	DBMS string
+	// answerKey explains the cause and the fix, the compose commands it
+	// mentions use composePlaceholder.
+	answerKey string
+
+	// edits are the changes made to the project files.
+	edits []edit
+}
+
+// An edit replaces text in one of the project files.
+type edit struct {
+	// file is the path of the file, relative to the project directory.
+	file string
+	old  string
+	new  string
+}
+
+// marker is the content of the marker file, it records the ID of the
+// scenario rather than its name so that reading it doesn't give the answer
+// away.
+type marker struct {
+	Scenario string `yaml:"scenario"`
+}
+
+// id returns the opaque identifier of the scenario recorded in the marker
+// file.
+func (s Scenario) id() string {
+	sum := sha256.Sum256([]byte(s.Name))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Answer returns the answer key of the scenario, which explains the cause and
+// the fix, with the compose command of the sandbox, for example
+// "podman compose". It is only shown on demand.
+func (s Scenario) Answer(compose string) string {
+	return strings.ReplaceAll(s.answerKey, composePlaceholder, compose)
+}
+
+// All returns every scenario.
+func All() []Scenario {
+	return library
+}
+
+// ForDBMS returns the scenarios that apply to the DBMS.
+func ForDBMS(DBMSName string) []Scenario {
+	found := []Scenario{}
+	for _, scenario := range library {
+		if scenario.DBMS == DBMSName {
+			found = append(found, scenario)
+		}
+	}
+
+	return found
+}
+
+// Get returns the scenario with the name.
+func Get(name string) (Scenario, bool) {
+	for _, scenario := range library {
+		if scenario.Name == name {
+			return scenario, true
+		}
+	}
+
+	return Scenario{}, false
+}
+
+// Apply breaks the project found in the directory and records the scenario
+// in the marker file.
+func (s Scenario) Apply(dir string) error {
+	for _, e := range s.edits {
+		path := filepath.Join(dir, filepath.FromSlash(e.file))
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("Failed to read %q, error: %q", path, err)
+		}
+
+		if !strings.Contains(string(content), e.old) {
+			return fmt.Errorf("The scenario %q can't be applied, %q doesn't contain the expected configuration", s.Name, e.file)
+		}
+
+		updated := strings.Replace(string(content), e.old, e.new, 1)
+		if err := os.WriteFile(path, []byte(updated), 0644); err != nil {
+			return fmt.Errorf("Failed to write to file: %q, error: %q", path, err)
+		}
+	}
+
+	content, err := yaml.Marshal(marker{Scenario: s.id()})
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, MarkerName)
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("Failed to write to file: %q, error: %q", path, err)
+	}
+
+	return nil
+}
+
+// Applied returns the scenario recorded in the marker file of the project,
+// ok is false when no scenario was applied.
+func Applied(dir string) (scenario Scenario, ok bool, err error) {
+	path := filepath.Join(dir, MarkerName)
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return scenario, false, nil
+	}
+	if err != nil {
+		return scenario, false, fmt.Errorf("Failed to read %q, error: %q", path, err)
+	}
+
+	var m marker
+	if err := yaml.Unmarshal(content, &m); err != nil {
+		return scenario, false, fmt.Errorf("Failed to parse %q, error: %q", path, err)
+	}
+
+	for _, scenario := range library {
+		if scenario.id() == m.Scenario {
+			return scenario, true, nil
+		}
+	}
+
+	return Scenario{}, false, fmt.Errorf("Unknown scenario %q found in %q", m.Scenario, path)
+}
@@ -1,23 +1,125 @@
 package styles
 
 import (
-	"github.com/charmbracelet/lipgloss"
+	"fmt"
+	"os"
+	"sort"
+
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
 const (
 	// Base TUI Colors
 	DatadogColor = "#632CA6"
 	WhiteColor   = "#FFF"
+	BlackColor   = "#000"
+	YellowColor  = "#FF0"
+
+	// noColorEnv is the environment variable that disables colored output,
+	// see https://no-color.org.
+	noColorEnv = "NO_COLOR"
 )
 
+// A Theme holds the colors used to render the TUI.
+type Theme struct {
+	// Name is the name used to select the theme.
+	Name string
+	// Accent is the color used for the banner, answers and selected options.
+	Accent string
+	// Foreground is the color used for questions and other text.
+	Foreground string
+}
+
 var (
+	// DarkTheme is the default theme, made for terminals with a dark background.
+	DarkTheme = Theme{Name: "dark", Accent: DatadogColor, Foreground: WhiteColor}
+	// LightTheme is made for terminals with a light background.
+	LightTheme = Theme{Name: "light", Accent: DatadogColor, Foreground: BlackColor}
+	// HighContrastTheme only uses colors with a high contrast on a dark
+	// background.
+	HighContrastTheme = Theme{Name: "high-contrast", Accent: YellowColor, Foreground: WhiteColor}
+
+	themes = map[string]Theme{
+		DarkTheme.Name:         DarkTheme,
+		LightTheme.Name:        LightTheme,
+		HighContrastTheme.Name: HighContrastTheme,
+	}
+
+	// Current is the theme currently in use.
+	Current Theme
+	// ASCIIOnly is true when the emojis and the banner should be replaced with
+	// plain ASCII text.
+	ASCIIOnly bool
+
+	// Bye is the text shown when the user quits a prompt.
+	Bye string
+)
+
+var (
+	DatadogColoredText lipgloss.Style
+	WhiteColoredText   lipgloss.Style
+	ProjectTitle       lipgloss.Style
+	Question           lipgloss.Style
+	Quitting           lipgloss.Style
+	Answer             lipgloss.Style
+	StatusIndicator    lipgloss.Style
+	Error              lipgloss.Style
+	Success            lipgloss.Style
+	Warning            lipgloss.Style
+	ListItemTitle      lipgloss.Style
+	ListPagination     lipgloss.Style
+	ListHelpStyle      lipgloss.Style
+)
+
+func init() {
+	Apply(DarkTheme, false)
+}
+
+// ThemeNames returns the names of all of the available themes.
+func ThemeNames() []string {
+	names := []string{}
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// GetTheme returns the theme with the given name, or an error if there is no
+// theme with that name.
+func GetTheme(name string) (Theme, error) {
+	theme, ok := themes[name]
+	if !ok {
+		return Theme{}, fmt.Errorf("Unknown theme %q, available themes: %q", name, ThemeNames())
+	}
+
+	return theme, nil
+}
+
+// Apply rebuilds all of the styles using the theme. When ascii is true the
+// emoji status indicators are replaced with plain text. Colors are disabled
+// when the NO_COLOR environment variable is set.
+func Apply(theme Theme, ascii bool) {
+	if os.Getenv(noColorEnv) != "" {
+		lipgloss.SetColorProfile(termenv.Ascii)
+		theme.Accent = ""
+		theme.Foreground = ""
+	}
+
+	Current = theme
+	ASCIIOnly = ascii
+
+	accent := lipgloss.Color(theme.Accent)
+	foreground := lipgloss.Color(theme.Foreground)
+
 	DatadogColoredText = lipgloss.NewStyle().
-		Foreground(lipgloss.Color(DatadogColor))
+		Foreground(accent)
 
 	WhiteColoredText = lipgloss.NewStyle().
-		Foreground(lipgloss.Color(WhiteColor))
-
+		Foreground(foreground)
 
 	ProjectTitle = DatadogColoredText.Copy().
 		MaxWidth(80).
@@ -28,29 +130,42 @@ var (
 		Margin(1, 4)
 
 	Quitting = WhiteColoredText.Copy().
-			Margin(0, 0, 2, 4)
+		Margin(0, 0, 2, 4)
 
 	Answer = lipgloss.NewStyle().
-			Foreground(lipgloss.Color(DatadogColor)).
-			Margin(1)
+		Foreground(accent).
+		Margin(1)
 
 	StatusIndicator = WhiteColoredText.Copy().
 		Padding(1, 1, 1, 4)
 
+	ListItemTitle = lipgloss.NewStyle().
+		Foreground(foreground).
+		MarginLeft(2)
+
+	ListPagination = list.DefaultStyles().
+		PaginationStyle.PaddingLeft(4)
+
+	ListHelpStyle = list.DefaultStyles().
+		HelpStyle.PaddingLeft(4).
+		PaddingBottom(1)
+
+	if ascii {
+		Error = StatusIndicator.Copy().SetString("[ERROR]")
+		Success = StatusIndicator.Copy().SetString("[OK]")
+		Warning = StatusIndicator.Copy().SetString("[WARN]")
+		Bye = "Bye"
+		return
+	}
+
 	Error = StatusIndicator.Copy().
 		SetString("🔴  ")
 
 	Success = StatusIndicator.Copy().
 		SetString("🟢  ")
 
-	ListItemTitle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color(WhiteColor)).
-			MarginLeft(2)
-
-	ListPagination = list.DefaultStyles().
-			PaginationStyle.PaddingLeft(4)
+	Warning = StatusIndicator.Copy().
+		SetString("🟡  ")
 
-	ListHelpStyle = list.DefaultStyles().
-			HelpStyle.PaddingLeft(4).
-			PaddingBottom(1)
-)
+	Bye = "👋 Bye"
+}
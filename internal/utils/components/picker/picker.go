@@ -17,11 +17,21 @@ const (
 
 type item struct {
 	title, desc string
+	isDefault   bool
+	disabled    bool
 }
 
-func (i item) Title() string       { return i.title }
+func (i item) Title() string {
+	switch {
+	case i.disabled:
+		return i.title + " (unavailable)"
+	case i.isDefault:
+		return i.title + " (default)"
+	}
+	return i.title
+}
 func (i item) Description() string { return i.desc }
-func (i item) FilterValue() string { return i.title }
+func (i item) FilterValue() string { return i.title + " " + i.desc }
 
 type model struct {
 	List     list.Model
@@ -38,24 +48,22 @@ func NewPicker(options []string, optionDesc []string, prompt string, output *str
 	items := []list.Item{}
 
 	itemDelegate := list.NewDefaultDelegate()
-	if optionDesc == nil {
-		itemDelegate.ShowDescription = false
+	itemDelegate.ShowDescription = hasDescriptions(optionDesc)
 
-		for _, option := range options {
-			items = append(items, item{title: option, desc: ""})
-		}
-	} else {
-		for ix, option := range options {
-			items = append(items, item{title: option, desc: optionDesc[ix]})
+	for ix, option := range options {
+		i := item{title: option}
+		if optionDesc != nil {
+			i.desc = optionDesc[ix]
 		}
+		items = append(items, i)
 	}
 
 	itemStyles := list.NewDefaultItemStyles()
 
 	itemStyles.SelectedTitle = lipgloss.NewStyle().
 		Border(lipgloss.NormalBorder(), false, false, false, true).
-		BorderForeground(lipgloss.Color(styles.DatadogColor)).
-		Foreground(lipgloss.Color(styles.DatadogColor)).
+		BorderForeground(lipgloss.Color(styles.Current.Accent)).
+		Foreground(lipgloss.Color(styles.Current.Accent)).
 		Padding(0, 0, 0, 2)
 	itemStyles.SelectedDesc = itemStyles.SelectedTitle.Copy()
 
@@ -64,7 +72,7 @@ func NewPicker(options []string, optionDesc []string, prompt string, output *str
 	l := list.New(items, itemDelegate, defaultWidth, defaultHeight)
 	l.Title = prompt
 	l.SetShowStatusBar(false)
-	l.SetFilteringEnabled(false)
+	l.SetFilteringEnabled(true)
 
 	l.Styles.Title = styles.ListItemTitle
 	l.Styles.PaginationStyle = styles.ListPagination
@@ -73,6 +81,52 @@ func NewPicker(options []string, optionDesc []string, prompt string, output *str
 	return model{List: l, output: output}
 }
 
+// hasDescriptions reports whether at least one of the options has a
+// description, otherwise there is no need to reserve space for them.
+func hasDescriptions(optionDesc []string) bool {
+	for _, desc := range optionDesc {
+		if desc != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WithDefault highlights the option as the default and moves the cursor to it.
+// Nothing happens if the option isn't one of the pickers options.
+func (m model) WithDefault(option string) model {
+	for ix, listItem := range m.List.Items() {
+		i := listItem.(item)
+		if i.title != option {
+			continue
+		}
+
+		i.isDefault = true
+		m.List.SetItem(ix, i)
+		m.List.Select(ix)
+		break
+	}
+
+	return m
+}
+
+// WithDisabled shows the options as unavailable, they are still listed but
+// can't be selected.
+func (m model) WithDisabled(options []string) model {
+	for ix, listItem := range m.List.Items() {
+		i := listItem.(item)
+		for _, option := range options {
+			if i.title == option {
+				i.disabled = true
+				m.List.SetItem(ix, i)
+			}
+		}
+	}
+
+	return m
+}
+
 func (m model) Init() tea.Cmd {
 	return nil
 }
@@ -84,6 +138,11 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		// While the user is typing a filter every key belongs to the filter
+		if m.List.SettingFilter() {
+			break
+		}
+
 		switch keypress := msg.String(); keypress {
 		case "q", "ctrl+c":
 			m.quitting = true
@@ -91,6 +150,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case "enter":
 			i, ok := m.List.SelectedItem().(item)
+			if ok && i.disabled {
+				return m, nil
+			}
 			if ok {
 				m.choice = string(i.title)
 				*m.output = string(i.title)
@@ -112,7 +174,7 @@ func (m model) View() string {
 	}
 
 	if m.quitting {
-		quitText := styles.Quitting.Render("No option selected, 👋 Bye")
+		quitText := styles.Quitting.Render("No option selected, " + styles.Bye)
 		return lipgloss.JoinVertical(lipgloss.Left, question, quitText)
 	}
 	return "\n" + m.List.View()
@@ -0,0 +1,233 @@
+package plain
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// Console is a line based terminal used when a full TUI is not available, for
+// example when running under a pipe or in a minimal container. All of the
+// questions created from the same Console share its reader so that buffered
+// input is not lost between questions.
+type Console struct {
+	in  *bufio.Reader
+	out io.Writer
+	// terminal is the file descriptor of in when it is a terminal, -1
+	// otherwise. Secrets are read from it without being echoed.
+	terminal int
+}
+
+// NewConsole returns a Console that reads answers from in and writes the
+// prompts to out.
+func NewConsole(in io.Reader, out io.Writer) *Console {
+	console := &Console{
+		in:       bufio.NewReader(in),
+		out:      out,
+		terminal: -1,
+	}
+
+	if file, ok := in.(*os.File); ok && term.IsTerminal(int(file.Fd())) {
+		console.terminal = int(file.Fd())
+	}
+
+	return console
+}
+
+// ReadLine reads a single line from the Console without the surrounding
+// whitespace. An io.EOF is only returned when nothing was read.
+func (c *Console) ReadLine() (string, error) {
+	line, err := c.in.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+		return "", err
+	}
+
+	return strings.TrimSpace(line), nil
+}
+
+// readPassword reads a line from the terminal without echoing it.
+func (c *Console) readPassword() (string, error) {
+	password, err := term.ReadPassword(c.terminal)
+	// The newline typed by the user isn't echoed either
+	fmt.Fprintln(c.out)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(password)), nil
+}
+
+type picker struct {
+	console    *Console
+	prompt     string
+	options    []string
+	optionDesc []string
+	defaultIx  int
+	disabled   map[int]bool
+	output     *string
+}
+
+// NewPicker returns a line based picker that implements the RunnableQuestion
+// interface. The options are printed as a numbered list and the user answers
+// with the number of the option they would like to select.
+func (c *Console) NewPicker(options []string, optionDesc []string, prompt string, output *string) picker {
+	return picker{
+		console:    c,
+		prompt:     prompt,
+		options:    options,
+		optionDesc: optionDesc,
+		defaultIx:  -1,
+		disabled:   map[int]bool{},
+		output:     output,
+	}
+}
+
+// WithDefault marks the option as the default, it is selected when the user
+// provides an empty answer. Nothing happens if the option isn't one of the
+// pickers options.
+func (p picker) WithDefault(option string) picker {
+	for ix, o := range p.options {
+		if o == option {
+			p.defaultIx = ix
+			break
+		}
+	}
+
+	return p
+}
+
+// WithDisabled shows the options as unavailable, they are still listed but
+// can't be selected.
+func (p picker) WithDisabled(options []string) picker {
+	for ix, o := range p.options {
+		for _, option := range options {
+			if o == option {
+				p.disabled[ix] = true
+			}
+		}
+	}
+
+	return p
+}
+
+func (p picker) Run() error {
+	fmt.Fprintln(p.console.out, p.prompt)
+	for ix, option := range p.options {
+		switch {
+		case p.disabled[ix]:
+			option += " (unavailable)"
+		case ix == p.defaultIx:
+			option += " (default)"
+		}
+		if p.optionDesc != nil && p.optionDesc[ix] != "" {
+			fmt.Fprintf(p.console.out, "  %d) %s - %s\n", ix+1, option, p.optionDesc[ix])
+			continue
+		}
+		fmt.Fprintf(p.console.out, "  %d) %s\n", ix+1, option)
+	}
+
+	for {
+		if p.defaultIx >= 0 {
+			fmt.Fprintf(p.console.out, "Enter a number [1-%d] (%d): ", len(p.options), p.defaultIx+1)
+		} else {
+			fmt.Fprintf(p.console.out, "Enter a number [1-%d]: ", len(p.options))
+		}
+
+		line, err := p.console.ReadLine()
+		if errors.Is(err, io.EOF) {
+			// No answer was provided, leaving the output empty lets the caller
+			// know that the user quit.
+			fmt.Fprintln(p.console.out)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if line == "" && p.defaultIx >= 0 {
+			*p.output = p.options[p.defaultIx]
+			return nil
+		}
+
+		choice, err := strconv.Atoi(line)
+		if err != nil || choice < 1 || choice > len(p.options) {
+			fmt.Fprintf(p.console.out, "%q is not a valid option\n", line)
+			continue
+		}
+
+		if p.disabled[choice-1] {
+			fmt.Fprintf(p.console.out, "%q is not available yet\n", p.options[choice-1])
+			continue
+		}
+
+		*p.output = p.options[choice-1]
+		return nil
+	}
+}
+
+type textInput struct {
+	console      *Console
+	prompt       string
+	defaultValue string
+	secret       bool
+	output       *string
+}
+
+// NewTextInput returns a line based text input that implements the
+// RunnableQuestion interface. The default value is shown in brackets and used
+// when the user provides an empty answer.
+func (c *Console) NewTextInput(prompt string, placeholder string, output *string) textInput {
+	return textInput{
+		console:      c,
+		prompt:       prompt,
+		defaultValue: placeholder,
+		output:       output,
+	}
+}
+
+// NewSecretInput returns a line based text input for secrets. When the
+// Console reads from a terminal the answer isn't echoed while it is typed,
+// otherwise, for example under a pipe, it is read as a line.
+func (c *Console) NewSecretInput(prompt string, output *string) textInput {
+	input := c.NewTextInput(prompt, "", output)
+	input.secret = true
+
+	return input
+}
+
+func (t textInput) Run() error {
+	if t.defaultValue != "" {
+		fmt.Fprintf(t.console.out, "%s [%s]: ", t.prompt, t.defaultValue)
+	} else {
+		fmt.Fprintf(t.console.out, "%s: ", t.prompt)
+	}
+
+	var line string
+	var err error
+	if t.secret && t.console.terminal >= 0 {
+		line, err = t.console.readPassword()
+	} else {
+		line, err = t.console.ReadLine()
+	}
+	if errors.Is(err, io.EOF) {
+		fmt.Fprintln(t.console.out)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if line == "" {
+		*t.output = t.defaultValue
+		return nil
+	}
+
+	*t.output = line
+	return nil
+}
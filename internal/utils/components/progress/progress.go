@@ -0,0 +1,181 @@
+package progress
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/aldrickdev/dbm-sandbox/internal/styles"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type Status int
+
+const (
+	Running Status = iota
+	Done
+	Failed
+)
+
+// errInterrupted is returned when the user quits the progress view before the
+// job is done.
+var errInterrupted = errors.New("Interrupted by the user")
+
+// An Update reports the new status of a step to the progress view.
+type Update func(step string, status Status)
+
+type step struct {
+	name   string
+	status Status
+}
+
+type (
+	stepMsg struct {
+		name   string
+		status Status
+	}
+	doneMsg struct {
+		err error
+	}
+)
+
+type model struct {
+	title   string
+	steps   []step
+	spinner spinner.Model
+	done    bool
+	err     error
+}
+
+// NewProgress returns a Bubble Tea application that lists each of the steps of
+// a job. A spinner is shown next to the running steps and a check or error
+// mark once they are done.
+func NewProgress(title string) model {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	if styles.ASCIIOnly {
+		s.Spinner = spinner.Line
+	}
+	s.Style = styles.DatadogColoredText
+
+	return model{
+		title:   title,
+		spinner: s,
+	}
+}
+
+func (m model) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case stepMsg:
+		for ix := range m.steps {
+			if m.steps[ix].name == msg.name {
+				m.steps[ix].status = msg.status
+				return m, nil
+			}
+		}
+		m.steps = append(m.steps, step{name: msg.name, status: msg.status})
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			m.err = errInterrupted
+			return m, tea.Quit
+		}
+		return m, nil
+
+	case doneMsg:
+		m.done = true
+		m.err = msg.err
+		return m, tea.Quit
+
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m model) View() string {
+	var view strings.Builder
+
+	view.WriteString(styles.Question.Render(m.title))
+	view.WriteString("\n")
+
+	for _, s := range m.steps {
+		view.WriteString("    ")
+		switch s.status {
+		case Running:
+			view.WriteString(m.spinner.View())
+		case Done:
+			view.WriteString(styles.DatadogColoredText.Render(Mark(Done)))
+		case Failed:
+			view.WriteString(styles.DatadogColoredText.Render(Mark(Failed)))
+		}
+		view.WriteString(" " + styles.WhiteColoredText.Render(s.name) + "\n")
+	}
+
+	return view.String()
+}
+
+// Mark returns the mark shown next to a step that is done or failed.
+func Mark(status Status) string {
+	switch status {
+	case Done:
+		if styles.ASCIIOnly {
+			return "[ok]"
+		}
+		return "✔"
+
+	case Failed:
+		if styles.ASCIIOnly {
+			return "[x]"
+		}
+		return "✘"
+
+	default:
+		if styles.ASCIIOnly {
+			return "[..]"
+		}
+		return "…"
+	}
+}
+
+// Run shows the progress view while the job runs. The job reports the status
+// of its steps using the Update it receives, and the error it returns is
+// returned by Run once the view is closed. When the user quits the view, the
+// context of the job is cancelled and Run waits for the job to return so that
+// nothing keeps running in the background.
+func (m model) Run(job func(context.Context, Update) error) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	program := tea.NewProgram(m)
+	jobDone := make(chan struct{})
+
+	go func() {
+		defer close(jobDone)
+
+		err := job(ctx, func(name string, status Status) {
+			program.Send(stepMsg{name: name, status: status})
+		})
+		program.Send(doneMsg{err: err})
+	}()
+
+	finalModel, err := program.Run()
+	cancel()
+	<-jobDone
+
+	if err != nil {
+		return err
+	}
+
+	return finalModel.(model).err
+}
@@ -2,6 +2,7 @@ package textInput
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/aldrickdev/dbm-sandbox/internal/styles"
 
@@ -29,6 +30,7 @@ type model struct {
 	input        string
 	output       *string
 	quitting     bool
+	secret       bool
 	err          error
 }
 
@@ -54,6 +56,28 @@ func NewTextInput(prompt string, placeholder string, output *string) model {
 	}
 }
 
+// NewSecretInput returns a Bubble Tea application that implements the
+// RunnableQuestion interface. It behaves like NewTextInput except that the
+// characters typed by the user are masked, which makes it suitable for
+// secrets like the Datadog API Key.
+func NewSecretInput(prompt string, output *string) model {
+	m := NewTextInput(prompt, "", output)
+	m.textInput.EchoMode = textinput.EchoPassword
+	m.textInput.EchoCharacter = maskCharacter()
+	m.secret = true
+
+	return m
+}
+
+// maskCharacter returns the character used to hide secrets.
+func maskCharacter() rune {
+	if styles.ASCIIOnly {
+		return '*'
+	}
+
+	return '•'
+}
+
 func (m model) Init() tea.Cmd {
 	return textinput.Blink
 }
@@ -95,12 +119,16 @@ func (m model) View() string {
 	question := styles.Question.Render(fmt.Sprint(m.prompt))
 
 	if m.input != "" {
-		selection := styles.Answer.Render(fmt.Sprint(*m.output))
+		answer := *m.output
+		if m.secret {
+			answer = strings.Repeat(string(maskCharacter()), 8)
+		}
+		selection := styles.Answer.Render(answer)
 		return lipgloss.JoinHorizontal(lipgloss.Bottom, question, selection)
 	}
 
 	if m.quitting {
-		quitText := styles.Quitting.Render("No value provided, 👋 Bye")
+		quitText := styles.Quitting.Render("No value provided, " + styles.Bye)
 		return lipgloss.JoinVertical(lipgloss.Left, question, quitText)
 	}
 
@@ -1,7 +1,6 @@
 package helpers
 
 import (
-	"embed"
 	"fmt"
 	"io/fs"
 	"log"
@@ -33,9 +32,19 @@ func CreateDirectory(name string) (err error) {
 	return err
 }
 
+// WriteFile creates the file with the content, replacing the file if it
+// already exists.
+func WriteFile(name string, content []byte) error {
+	if err := os.WriteFile(name, content, 0644); err != nil {
+		return fmt.Errorf("Failed to write to file: %q, error: %q", name, err)
+	}
+
+	return nil
+}
+
 // GetFSTree will create a slice of fileType that represents the file
-// structure in the embedded filesystem.
-func GetFSTree(eFileSystem embed.FS, source string) ([]fileType, error) {
+// structure in the filesystem.
+func GetFSTree(eFileSystem fs.FS, source string) ([]fileType, error) {
 	// Example source: embed/docker/postgres
 	//
 	// Given the file structure:
@@ -119,7 +128,7 @@ func GetFSTree(eFileSystem embed.FS, source string) ([]fileType, error) {
 
 // CreateProjectTree creates the directory structure in the destination directory 
 // that matches the embed FS 
-func CreateProjectTree(eFileSystem embed.FS, dbms, destination string, tree []fileType) error {
+func CreateProjectTree(eFileSystem fs.FS, dbms, destination string, tree []fileType) error {
 	for _, element := range tree {
 		if element.isDir {
 			foundDirName := dbms + "/" + element.name
@@ -153,7 +162,7 @@ func CreateProjectTree(eFileSystem embed.FS, dbms, destination string, tree []fi
 
 // CopyDirectoryFS will create a copy of the embedded file system, on the
 // users machine for the selected DBMS.
-func CopyDirectoryFS(eFileSystem embed.FS, dbms, destination string) error {
+func CopyDirectoryFS(eFileSystem fs.FS, dbms, destination string) error {
 	var sourceTree []fileType
 
 	tree, err := GetFSTree(eFileSystem, dbms)
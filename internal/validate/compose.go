@@ -0,0 +1,292 @@
+package validate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// serviceName matches the names compose accepts for services.
+var serviceName = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+// composeKeys are the top level keys of the compose specification.
+var composeKeys = keySet("version", "name", "services", "networks", "volumes", "configs", "secrets", "include")
+
+// serviceKeys are the keys of a service in the compose specification.
+var serviceKeys = keySet(
+	"annotations", "attach", "blkio_config", "build", "cap_add", "cap_drop", "cgroup", "cgroup_parent",
+	"command", "configs", "container_name", "cpu_count", "cpu_percent", "cpu_period", "cpu_quota",
+	"cpu_rt_period", "cpu_rt_runtime", "cpu_shares", "cpus", "cpuset", "credential_spec", "depends_on",
+	"deploy", "develop", "device_cgroup_rules", "devices", "dns", "dns_opt", "dns_search", "domainname",
+	"entrypoint", "env_file", "environment", "expose", "extends", "external_links", "extra_hosts",
+	"group_add", "healthcheck", "hostname", "image", "init", "ipc", "isolation", "labels", "links",
+	"logging", "mac_address", "mem_limit", "mem_reservation", "mem_swappiness", "memswap_limit",
+	"network_mode", "networks", "oom_kill_disable", "oom_score_adj", "pid", "pids_limit", "platform",
+	"ports", "privileged", "profiles", "pull_policy", "read_only", "restart", "runtime", "scale",
+	"secrets", "security_opt", "shm_size", "stdin_open", "stop_grace_period", "stop_signal",
+	"storage_opt", "sysctls", "tmpfs", "tty", "ulimits", "user", "userns_mode", "uts", "volumes",
+	"volumes_from", "working_dir",
+)
+
+// composeProject is the part of the compose file the integrations are
+// validated against.
+type composeProject struct {
+	// services holds the environment of each service, by name.
+	services map[string]map[string]string
+}
+
+// keySet returns a set of the keys.
+func keySet(keys ...string) map[string]bool {
+	set := map[string]bool{}
+	for _, key := range keys {
+		set[key] = true
+	}
+
+	return set
+}
+
+// allowedKey reports whether the key is in the set or is an x- extension.
+func allowedKey(set map[string]bool, key string) bool {
+	return set[key] || strings.HasPrefix(key, "x-")
+}
+
+// validateCompose checks the compose file against the structure of the
+// compose specification, and that the files it mounts exist.
+func validateCompose(dir string) (composeProject, []Problem) {
+	compose := composeProject{services: map[string]map[string]string{}}
+
+	var content map[string]interface{}
+	if problem := readYAML(dir, ComposeFile, &content); problem != nil {
+		return compose, []Problem{*problem}
+	}
+
+	problems := []Problem{}
+	for _, key := range sortedKeys(content) {
+		if !allowedKey(composeKeys, key) {
+			problems = append(problems, problemf(ComposeFile, "unknown top level key %q", key))
+		}
+	}
+
+	services, ok := content["services"].(map[string]interface{})
+	if !ok || len(services) == 0 {
+		return compose, append(problems, problemf(ComposeFile, "services must be a non-empty mapping"))
+	}
+
+	for _, name := range sortedKeys(services) {
+		service, ok := services[name].(map[string]interface{})
+		if !ok {
+			problems = append(problems, problemf(ComposeFile, "the service %q must be a mapping", name))
+			continue
+		}
+
+		environment, serviceProblems := validateService(dir, name, service)
+		problems = append(problems, serviceProblems...)
+		compose.services[name] = environment
+	}
+
+	for _, name := range sortedKeys(services) {
+		service, _ := services[name].(map[string]interface{})
+		for _, dependency := range dependencies(service["depends_on"]) {
+			if _, ok := services[dependency]; !ok {
+				problems = append(problems, problemf(ComposeFile, "the service %q depends on the unknown service %q", name, dependency))
+			}
+		}
+	}
+
+	return compose, problems
+}
+
+// validateService checks the service and returns its environment.
+func validateService(dir string, name string, service map[string]interface{}) (map[string]string, []Problem) {
+	problems := []Problem{}
+	invalid := func(format string, args ...interface{}) {
+		problems = append(problems, problemf(ComposeFile, "the service %q %s", name, fmt.Sprintf(format, args...)))
+	}
+
+	if !serviceName.MatchString(name) {
+		invalid("has an invalid name")
+	}
+
+	for _, key := range sortedKeys(service) {
+		if !allowedKey(serviceKeys, key) {
+			invalid("has the unknown key %q", key)
+		}
+	}
+
+	image, hasImage := service["image"]
+	if _, hasBuild := service["build"]; !hasImage && !hasBuild {
+		invalid("has neither an image nor a build")
+	}
+	if image, ok := image.(string); hasImage && (!ok || image == "") {
+		invalid("must have a non-empty image")
+	}
+
+	for _, key := range []string{"command", "entrypoint"} {
+		if value, ok := service[key]; ok && !isString(value) && !isStringList(value) {
+			invalid("%s must be a string or a list of strings", key)
+		}
+	}
+
+	for _, key := range []string{"security_opt", "cap_add", "cap_drop", "profiles"} {
+		if value, ok := service[key]; ok && !isStringList(value) {
+			invalid("%s must be a list of strings", key)
+		}
+	}
+
+	if value, ok := service["ports"]; ok {
+		if _, isList := value.([]interface{}); !isList {
+			invalid("ports must be a list")
+		}
+	}
+
+	environment, ok := parseEnvironment(service["environment"])
+	if !ok {
+		invalid("environment must be a list of KEY=VALUE strings or a mapping")
+	}
+
+	if value, ok := service["volumes"]; ok {
+		volumes, isList := value.([]interface{})
+		if !isList {
+			invalid("volumes must be a list")
+		}
+
+		for _, volume := range volumes {
+			if problem := validateVolume(dir, volume); problem != "" {
+				invalid("%s", problem)
+			}
+		}
+	}
+
+	return environment, problems
+}
+
+// validateVolume checks a volume of a service, the sources of the bind mounts
+// relative to the project must exist. It returns a description of the
+// problem, empty when the volume is valid.
+func validateVolume(dir string, volume interface{}) string {
+	var source, target string
+	switch volume := volume.(type) {
+	case string:
+		parts := strings.Split(volume, ":")
+		if len(parts) == 1 {
+			return ""
+		}
+		if len(parts) > 3 {
+			return fmt.Sprintf("has the invalid volume %q", volume)
+		}
+		source, target = parts[0], parts[1]
+
+	case map[string]interface{}:
+		source, _ = volume["source"].(string)
+		target, _ = volume["target"].(string)
+
+	default:
+		return fmt.Sprintf("has the invalid volume %v", volume)
+	}
+
+	if target == "" || !strings.HasPrefix(target, "/") {
+		return fmt.Sprintf("mounts %q to %q which isn't an absolute path", source, target)
+	}
+
+	if strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../") {
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(source))); err != nil {
+			return fmt.Sprintf("mounts %q which doesn't exist in the project", source)
+		}
+	}
+
+	return ""
+}
+
+// parseEnvironment returns the environment of a service, given either as a
+// list of KEY=VALUE strings or as a mapping.
+func parseEnvironment(value interface{}) (map[string]string, bool) {
+	environment := map[string]string{}
+
+	switch value := value.(type) {
+	case nil:
+		return environment, true
+
+	case []interface{}:
+		for _, variable := range value {
+			variable, ok := variable.(string)
+			if !ok {
+				return environment, false
+			}
+
+			key, setting, _ := strings.Cut(variable, "=")
+			environment[key] = setting
+		}
+		return environment, true
+
+	case map[string]interface{}:
+		for key, setting := range value {
+			switch setting.(type) {
+			case map[string]interface{}, []interface{}:
+				return environment, false
+			}
+
+			if setting != nil {
+				environment[key] = fmt.Sprint(setting)
+			}
+		}
+		return environment, true
+
+	default:
+		return environment, false
+	}
+}
+
+// dependencies returns the services listed in depends_on, given either as a
+// list or as a mapping.
+func dependencies(value interface{}) []string {
+	names := []string{}
+
+	switch value := value.(type) {
+	case []interface{}:
+		for _, name := range value {
+			names = append(names, fmt.Sprint(name))
+		}
+
+	case map[string]interface{}:
+		names = sortedKeys(value)
+	}
+
+	return names
+}
+
+// isString reports whether the value is a string.
+func isString(value interface{}) bool {
+	_, ok := value.(string)
+	return ok
+}
+
+// isStringList reports whether the value is a list of strings.
+func isStringList(value interface{}) bool {
+	list, ok := value.([]interface{})
+	if !ok {
+		return false
+	}
+
+	for _, item := range list {
+		if !isString(item) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sortedKeys returns the keys of the mapping, sorted so that the problems are
+// reported in a stable order.
+func sortedKeys(mapping map[string]interface{}) []string {
+	keys := []string{}
+	for key := range mapping {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,302 @@
+package validate
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	// postgresUser captures the name and the password of the users created in
+	// the Postgres init SQL.
+	postgresUser = regexp.MustCompile(`(?i)\bCREATE\s+(?:USER|ROLE)\s+"?(\w+)"?\s+(?:WITH\s+)?(?:LOGIN\s+)?PASSWORD\s+'([^']*)'`)
+	// mysqlUser captures the name and the password of the users created in
+	// the MySQL init SQL.
+	mysqlUser = regexp.MustCompile("(?i)\\bCREATE\\s+USER\\s+(?:IF\\s+NOT\\s+EXISTS\\s+)?['`\"]?(\\w+)['`\"]?@\\S+\\s+IDENTIFIED\\s+BY\\s+'([^']*)'")
+)
+
+// An integration is the agent integration of a DBMS, and where the sandbox
+// creates the user it connects with.
+type integration struct {
+	// service is the compose service of the DBMS.
+	service string
+	// port is the port the DBMS listens on in its container.
+	port int
+	// initSQL is the glob of the init SQL files creating the users, relative
+	// to the project directory.
+	initSQL string
+	// user captures the name and the password of the users created by the
+	// init SQL.
+	user *regexp.Regexp
+	// adminUser and adminPassword are the user created by the DBMS image and
+	// the environment variable of the service holding its password.
+	adminUser     string
+	adminPassword string
+}
+
+// integrations holds the integrations by the name of their conf.d directory.
+var integrations = map[string]integration{
+	"postgres.d": {
+		service: "postgres",
+		port:    5432,
+		initSQL: "postgres/*.sql",
+		user:    postgresUser,
+	},
+	"mysql.d": {
+		service: "mysql",
+		port:    3306,
+		initSQL: "mysql/init-sql/*.sql",
+		user:    mysqlUser,
+	},
+	"sqlserver.d": {
+		service:       "ssql",
+		port:          1433,
+		adminUser:     "sa",
+		adminPassword: "MSSQL_SA_PASSWORD",
+	},
+}
+
+// agentConf is the part of the configuration of an integration that is
+// validated.
+type agentConf struct {
+	Instances []agentInstance `yaml:"instances"`
+}
+
+// agentInstance is an instance of the configuration of an integration, the
+// fields that can be given different types are validated by hand.
+type agentInstance struct {
+	Host     string      `yaml:"host"`
+	Port     interface{} `yaml:"port"`
+	Username string      `yaml:"username"`
+	Password string      `yaml:"password"`
+	DBM      interface{} `yaml:"dbm"`
+}
+
+// validateIntegrations checks the agent configuration of each DBMS
+// integration found in conf.d against the compose services and the users
+// created by the init SQL.
+func validateIntegrations(dir string, compose composeProject) []Problem {
+	confs, err := filepath.Glob(filepath.Join(dir, "conf.d", "*.d", "conf.yaml"))
+	if err != nil {
+		return []Problem{problemf("conf.d", "failed to list the integrations: %s", err)}
+	}
+	sort.Strings(confs)
+
+	problems := []Problem{}
+	found := false
+	for _, conf := range confs {
+		name := filepath.Base(filepath.Dir(conf))
+		i, ok := integrations[name]
+		if !ok {
+			continue
+		}
+		found = true
+
+		problems = append(problems, i.validate(dir, path.Join("conf.d", name, "conf.yaml"), compose)...)
+	}
+
+	if !found {
+		problems = append(problems, problemf("conf.d", "no Postgres, MySQL or SQL Server integration is configured"))
+	}
+
+	return problems
+}
+
+// Credentials checks that every instance of the integration, found in
+// conf.d/<name>/conf.yaml, uses a user the sandbox creates with the same
+// password.
+func Credentials(dir string, name string) []Problem {
+	file := path.Join("conf.d", name, "conf.yaml")
+	i, ok := integrations[name]
+	if !ok {
+		return []Problem{problemf(file, "%q isn't a Postgres, MySQL or SQL Server integration", name)}
+	}
+
+	conf, problems := readAgentConf(dir, file)
+	if len(problems) > 0 {
+		return problems
+	}
+
+	// Only the environment of the services is needed, the problems of the
+	// compose file are reported by Project
+	compose, _ := validateCompose(dir)
+	for ix, instance := range conf.Instances {
+		if message := i.validateCredentials(dir, compose, instance); message != "" {
+			problems = append(problems, problemf(file, "instance %d %s", ix+1, message))
+		}
+	}
+
+	return problems
+}
+
+// readAgentConf parses the configuration of an integration, which must have
+// at least one instance.
+func readAgentConf(dir string, file string) (agentConf, []Problem) {
+	var conf agentConf
+	if problem := readYAML(dir, file, &conf); problem != nil {
+		return conf, []Problem{*problem}
+	}
+
+	if len(conf.Instances) == 0 {
+		return conf, []Problem{problemf(file, "instances must have at least one instance")}
+	}
+
+	return conf, nil
+}
+
+// validate checks the configuration of the integration found in the file.
+func (i integration) validate(dir string, file string, compose composeProject) []Problem {
+	conf, problems := readAgentConf(dir, file)
+	if len(problems) > 0 {
+		return problems
+	}
+
+	for ix, instance := range conf.Instances {
+		invalid := func(format string, args ...interface{}) {
+			problems = append(problems, problemf(file, "instance %d %s", ix+1, fmt.Sprintf(format, args...)))
+		}
+
+		// The SQL Server integration accepts the port in the host, as
+		// host,port
+		host, hostPort, hasHostPort := strings.Cut(instance.Host, ",")
+		host = strings.TrimSpace(host)
+		switch {
+		case host == "":
+			invalid("must have a host")
+
+		case host != i.service:
+			invalid("has the host %q, expected the compose service %q", host, i.service)
+
+		case compose.services[host] == nil:
+			invalid("has the host %q which isn't a service of %s", host, ComposeFile)
+		}
+
+		port, ok := parsePort(instance.Port)
+		if instance.Port == nil && hasHostPort {
+			port, ok = parsePort(strings.TrimSpace(hostPort))
+		}
+		switch {
+		case instance.Port == nil && !hasHostPort:
+			invalid("must have a port")
+
+		case !ok:
+			invalid("has an invalid port")
+
+		case port != i.port:
+			invalid("has the port %d, the DBMS listens on %d", port, i.port)
+		}
+
+		if instance.Username == "" {
+			invalid("must have a username")
+		}
+
+		if dbm, ok := instance.DBM.(bool); !ok || !dbm {
+			invalid("must have dbm: true")
+		}
+
+		if instance.Username != "" {
+			if message := i.validateCredentials(dir, compose, instance); message != "" {
+				invalid("%s", message)
+			}
+		}
+	}
+
+	return problems
+}
+
+// validateCredentials checks that the user of the instance is created by the
+// sandbox with the same password. It returns a description of the problem,
+// empty when the credentials match.
+func (i integration) validateCredentials(dir string, compose composeProject, instance agentInstance) string {
+	if i.adminUser != "" && instance.Username == i.adminUser {
+		password, ok := compose.services[i.service][i.adminPassword]
+		if !ok {
+			return fmt.Sprintf("uses %q but %s isn't set on the %q service", i.adminUser, i.adminPassword, i.service)
+		}
+		if password != instance.Password {
+			return fmt.Sprintf("uses a password for %q that doesn't match %s of the %q service", i.adminUser, i.adminPassword, i.service)
+		}
+		return ""
+	}
+
+	if i.user == nil {
+		return fmt.Sprintf("uses the user %q, only %q is created", instance.Username, i.adminUser)
+	}
+
+	passwords, err := i.createdUsers(dir)
+	if err != nil {
+		return err.Error()
+	}
+
+	password, ok := passwords[instance.Username]
+	if !ok {
+		return fmt.Sprintf("uses the user %q which isn't created in %s", instance.Username, i.initSQL)
+	}
+	if password != instance.Password {
+		return fmt.Sprintf("uses a password for %q that doesn't match the one created in %s", instance.Username, i.initSQL)
+	}
+
+	return ""
+}
+
+// createdUsers returns the password of each user created by the init SQL.
+func (i integration) createdUsers(dir string) (map[string]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, filepath.FromSlash(i.initSQL)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %s", i.initSQL, err)
+	}
+	sort.Strings(files)
+
+	passwords := map[string]string{}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %s", file, err)
+		}
+
+		for _, match := range i.user.FindAllStringSubmatch(StripSQLComments(string(content)), -1) {
+			passwords[match[1]] = match[2]
+		}
+	}
+
+	return passwords, nil
+}
+
+// parsePort returns the port, given as a number or a string.
+func parsePort(value interface{}) (int, bool) {
+	var port int
+	switch value := value.(type) {
+	case int:
+		port = value
+
+	case string:
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, false
+		}
+		port = parsed
+
+	default:
+		return 0, false
+	}
+
+	return port, port > 0 && port < 65536
+}
+
+// StripSQLComments removes the -- comments from the SQL, so that commented
+// out statements aren't found.
+func StripSQLComments(content string) string {
+	lines := strings.Split(content, "\n")
+	for ix, line := range lines {
+		if before, _, found := strings.Cut(line, "--"); found {
+			lines[ix] = before
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,69 @@
+package validate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ComposeFile is the name of the compose file of a Docker or Podman project.
+const ComposeFile = "docker-compose.yaml"
+
+// A Problem is something wrong found in a generated project.
+type Problem struct {
+	// File is the path of the file, relative to the project directory.
+	File    string
+	Message string
+}
+
+// String returns the problem prefixed with its file.
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s", p.File, p.Message)
+}
+
+// Project validates the Docker or Podman project found in the directory, its
+// compose file, the agent configuration of each integration and the
+// credentials they use, without running anything.
+func Project(dir string) []Problem {
+	compose, problems := validateCompose(dir)
+	return append(problems, validateIntegrations(dir, compose)...)
+}
+
+// Error returns an error listing the problems, nil when there are none.
+func Error(problems []Problem) error {
+	if len(problems) == 0 {
+		return nil
+	}
+
+	messages := []string{}
+	for _, problem := range problems {
+		messages = append(messages, problem.String())
+	}
+
+	return fmt.Errorf("The project is invalid: %s", strings.Join(messages, "; "))
+}
+
+// problemf returns a problem of the file.
+func problemf(file string, format string, args ...interface{}) Problem {
+	return Problem{File: file, Message: fmt.Sprintf(format, args...)}
+}
+
+// readYAML parses the YAML file of the project into the value, the problem is
+// nil when it succeeds.
+func readYAML(dir string, name string, value interface{}) *Problem {
+	content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		problem := problemf(name, "failed to read the file: %s", err)
+		return &problem
+	}
+
+	if err := yaml.Unmarshal(content, value); err != nil {
+		problem := problemf(name, "failed to parse the file: %s", err)
+		return &problem
+	}
+
+	return nil
+}
@@ -0,0 +1,235 @@
+package validate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	compose = `services:
+  datadog-agent:
+    image: gcr.io/datadoghq/agent:7
+    environment:
+    - "DD_API_KEY=key"
+    volumes:
+    - './conf.d/postgres.d:/etc/datadog-agent/conf.d/postgres.d'
+
+  postgres:
+    image: postgres:16
+    environment:
+    - "POSTGRES_PASSWORD=root"
+    volumes:
+    - './postgres/init.sql:/docker-entrypoint-initdb.d/init.sql'
+`
+	postgresConf = `init_config:
+instances:
+- host: postgres
+  dbm: true
+  port: 5432
+  username: datadog
+  password: root
+`
+	initSQL = `-- CREATE USER datadog WITH PASSWORD 'commented';
+CREATE USER datadog WITH PASSWORD 'root';
+`
+)
+
+// writeProject writes a Postgres project with the files, the ones that
+// aren't given are valid and the empty ones aren't written.
+func writeProject(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	project := map[string]string{
+		ComposeFile:                   compose,
+		"conf.d/postgres.d/conf.yaml": postgresConf,
+		"postgres/init.sql":           initSQL,
+	}
+	for name, content := range files {
+		project[name] = content
+	}
+
+	dir := t.TempDir()
+	for name, content := range project {
+		if content == "" {
+			continue
+		}
+
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func TestProjectIsValid(t *testing.T) {
+	if problems := Project(writeProject(t, nil)); len(problems) != 0 {
+		t.Fatalf("unexpected problems: %v", problems)
+	}
+
+	if err := Error(nil); err != nil {
+		t.Errorf("Error returned %q without problems", err)
+	}
+}
+
+func TestProjectProblems(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		problem string
+	}{
+		{
+			name:    "unknown top level key",
+			files:   map[string]string{ComposeFile: compose + "service:\n  mysql: {}\n"},
+			problem: `unknown top level key "service"`,
+		},
+		{
+			name:    "unknown service key",
+			files:   map[string]string{ComposeFile: strings.Replace(compose, "    volumes:\n    - './postgres", "    volume:\n    - './postgres", 1)},
+			problem: `the service "postgres" has the unknown key "volume"`,
+		},
+		{
+			name:    "missing image",
+			files:   map[string]string{ComposeFile: strings.Replace(compose, "    image: postgres:16\n", "", 1)},
+			problem: `the service "postgres" has neither an image nor a build`,
+		},
+		{
+			name:    "missing mount",
+			files:   map[string]string{ComposeFile: strings.Replace(compose, "./postgres/init.sql", "./postgres/setup.sql", 1)},
+			problem: `mounts "./postgres/setup.sql" which doesn't exist in the project`,
+		},
+		{
+			name:    "invalid environment",
+			files:   map[string]string{ComposeFile: strings.Replace(compose, `- "POSTGRES_PASSWORD=root"`, `- POSTGRES_PASSWORD: root`, 1)},
+			problem: `environment must be a list of KEY=VALUE strings or a mapping`,
+		},
+		{
+			name:    "host isn't a service",
+			files:   map[string]string{"conf.d/postgres.d/conf.yaml": strings.Replace(postgresConf, "host: postgres", "host: localhost", 1)},
+			problem: `has the host "localhost", expected the compose service "postgres"`,
+		},
+		{
+			name:    "missing port",
+			files:   map[string]string{"conf.d/postgres.d/conf.yaml": strings.Replace(postgresConf, "  port: 5432\n", "", 1)},
+			problem: `instance 1 must have a port`,
+		},
+		{
+			name:    "dbm disabled",
+			files:   map[string]string{"conf.d/postgres.d/conf.yaml": strings.Replace(postgresConf, "dbm: true", "dbm: false", 1)},
+			problem: `instance 1 must have dbm: true`,
+		},
+		{
+			name:    "missing username",
+			files:   map[string]string{"conf.d/postgres.d/conf.yaml": strings.Replace(postgresConf, "  username: datadog\n", "", 1)},
+			problem: `instance 1 must have a username`,
+		},
+		{
+			name:    "wrong password",
+			files:   map[string]string{"conf.d/postgres.d/conf.yaml": strings.Replace(postgresConf, "password: root", "password: commented", 1)},
+			problem: `uses a password for "datadog" that doesn't match`,
+		},
+		{
+			name:    "user not created",
+			files:   map[string]string{"postgres/init.sql": "-- No user\n"},
+			problem: `uses the user "datadog" which isn't created`,
+		},
+		{
+			name:    "no instance",
+			files:   map[string]string{"conf.d/postgres.d/conf.yaml": "init_config:\n"},
+			problem: `instances must have at least one instance`,
+		},
+		{
+			name:    "no integration",
+			files:   map[string]string{"conf.d/postgres.d/conf.yaml": "", "conf.d/redis.d/conf.yaml": postgresConf},
+			problem: `no Postgres, MySQL or SQL Server integration is configured`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			problems := Project(writeProject(t, test.files))
+
+			err := Error(problems)
+			if err == nil {
+				t.Fatal("no problem found")
+			}
+			if !strings.Contains(err.Error(), test.problem) {
+				t.Errorf("%q doesn't contain %q", err, test.problem)
+			}
+		})
+	}
+}
+
+func TestSQLServerUsesTheSAPassword(t *testing.T) {
+	dir := writeProject(t, map[string]string{
+		ComposeFile: `services:
+  ssql:
+    image: mcr.microsoft.com/mssql/server:2022-latest
+    environment:
+      ACCEPT_EULA: "Y"
+      MSSQL_SA_PASSWORD: Password1!
+`,
+		"conf.d/postgres.d/conf.yaml": "",
+		"conf.d/sqlserver.d/conf.yaml": `instances:
+  - dbm: true
+    host: 'ssql,1433'
+    username: sa
+    password: Password1!
+`,
+	})
+	if problems := Project(dir); len(problems) != 0 {
+		t.Fatalf("unexpected problems: %v", problems)
+	}
+
+	conf := filepath.Join(dir, "conf.d", "sqlserver.d", "conf.yaml")
+	content, _ := os.ReadFile(conf)
+	if err := os.WriteFile(conf, []byte(strings.Replace(string(content), "Password1!", "Password2!", 1)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Error(Project(dir)); err == nil || !strings.Contains(err.Error(), "MSSQL_SA_PASSWORD") {
+		t.Errorf("the wrong sa password isn't reported: %v", err)
+	}
+}
+
+func TestServiceNames(t *testing.T) {
+	tests := []struct {
+		name  string
+		valid bool
+	}{
+		{name: "a", valid: true},
+		{name: "datadog-agent", valid: true},
+		{name: "db_1.primary", valid: true},
+		{name: "my service", valid: false},
+		{name: "db:1", valid: false},
+	}
+
+	for _, test := range tests {
+		_, problems := validateService(t.TempDir(), test.name, map[string]interface{}{"image": "postgres:16"})
+		if valid := len(problems) == 0; valid != test.valid {
+			t.Errorf("the service name %q is valid: %t, expected %t, problems: %v", test.name, valid, test.valid, problems)
+		}
+	}
+}
+
+func TestCredentials(t *testing.T) {
+	dir := writeProject(t, nil)
+	if problems := Credentials(dir, "postgres.d"); len(problems) != 0 {
+		t.Fatalf("unexpected problems: %v", problems)
+	}
+
+	dir = writeProject(t, map[string]string{"postgres/init.sql": strings.Replace(initSQL, "PASSWORD 'root'", "PASSWORD 'r00t'", 1)})
+	if err := Error(Credentials(dir, "postgres.d")); err == nil || !strings.Contains(err.Error(), `uses a password for "datadog" that doesn't match`) {
+		t.Errorf("the wrong password isn't reported: %v", err)
+	}
+
+	if problems := Credentials(dir, "redis.d"); len(problems) != 1 {
+		t.Errorf("the unknown integration isn't reported: %v", problems)
+	}
+}